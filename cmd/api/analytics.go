@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/brianrahadi/sfucourses-api/internal/analytics"
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/brianrahadi/sfucourses-api/internal/store"
+)
+
+// @Summary		Get timetable heatmap
+// @Description	Aggregates the weekly scheduled class-hours of a term by campus, weekday and 30-minute slot, optionally filtered by campus, department and section type
+// @Tags			Analytics
+// @Accept			json
+// @Produce		json
+// @Param			term	query		string					true	"Year and term in format YYYY-Term (e.g., 2024-spring)"
+// @Param			campus	query		string					false	"Campus (e.g., Burnaby, Surrey)"
+// @Param			dept	query		string					false	"Department code (e.g., cmpt, math)"
+// @Param			type	query		string					false	"Section type (e.g., LEC, TUT, LAB)"
+// @Success		200		{object}	model.TimetableHeatmap	"Timetable heatmap"
+// @Failure		400		{object}	ErrorResponse			"Invalid year-term format or query parameters"
+// @Failure		404		{object}	ErrorResponse			"No sections found for the specified criteria"
+// @Failure		500		{object}	ErrorResponse			"Internal server error"
+// @Router			/v1/rest/analytics/timetable [get]
+func (app *application) getTimetableHeatmap(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	yearTerm := r.URL.Query().Get("term")
+	campus := r.URL.Query().Get("campus")
+	dept := r.URL.Query().Get("dept")
+	sectionCode := r.URL.Query().Get("type")
+
+	if yearTerm == "" {
+		app.badRequestResponse(w, r, errors.New("term parameter is required"))
+		return
+	}
+
+	year, term, err := splitYearTerm(yearTerm)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	courses, err := app.store.Sections.Get(ctx, year, term, dept, "")
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	campuses := analytics.BuildTimetableHeatmap(courses, analytics.TimetableOptions{
+		Campus:      campus,
+		SectionCode: sectionCode,
+	})
+	if len(campuses) == 0 {
+		app.notFoundResponse(w, r, store.ErrNotFound)
+		return
+	}
+
+	heatmap := model.TimetableHeatmap{
+		Term:        strings.ToLower(yearTerm),
+		Dept:        strings.ToUpper(dept),
+		SectionCode: strings.ToUpper(sectionCode),
+		SlotMinutes: analytics.SlotMinutes,
+		Campuses:    campuses,
+	}
+
+	if err := writeJSON(w, http.StatusOK, heatmap); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+}
@@ -1,38 +1,77 @@
 package main
 
 import (
-	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
+	"net/netip"
 	"os"
 	"os/exec"
+	"os/signal"
 	"strconv"
-	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/MarceloPetrucio/go-scalar-api-reference"
+	"github.com/brianrahadi/sfucourses-api/internal/metrics"
+	"github.com/brianrahadi/sfucourses-api/internal/ratelimit"
 	"github.com/brianrahadi/sfucourses-api/internal/store"
 	"github.com/go-co-op/gocron"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// shutdownTimeout bounds draining requests and syncs on shutdown, within
+	// the 30 seconds hosts give before killing the server
+	shutdownTimeout = 25 * time.Second
+
+	// syncKillDelay is how long a canceled sync command has to exit after
+	// SIGTERM before it's killed
+	syncKillDelay = 3 * time.Second
 )
 
 // application represents the application structure with configuration and data store
 type application struct {
 	config config
 	store  store.Storage
+	logger *slog.Logger
 
 	lastDataUpdate     time.Time
 	lastDataUpdateLock sync.RWMutex
+
+	// syncCtx is canceled when the server shuts down, stopping data syncs
+	syncCtx context.Context
+	syncs   sync.WaitGroup
+
+	// syncMu guards syncsStopped, which is set once shutdown starts so that
+	// no sync is added to syncs while shutdown waits for them
+	syncMu       sync.Mutex
+	syncsStopped bool
 }
 
+// errSyncsStopped is returned for syncs started after shutdown began
+var errSyncsStopped = errors.New("data syncs stopped for shutdown")
+
 // config holds the application configuration
 type config struct {
-	addr   string
-	env    string
-	apiURL string
+	addr           string
+	env            string
+	apiURL         string
+	trustedProxies []netip.Prefix // proxies whose X-Forwarded-For is trusted for client IPs
+	rateLimit      rateLimitConfig
+}
+
+// rateLimitConfig holds the rate limits of clients by route
+type rateLimitConfig struct {
+	enabled      bool
+	defaultLimit ratelimit.Limit
+	routeLimits  map[string]ratelimit.Limit // by route pattern, such as "GET /v1/rest/outlines"
+	apiKeys      map[string]bool
 }
 
 // dbConfig holds the database configuration
@@ -43,12 +82,6 @@ type dbConfig struct {
 	maxIdleTime  string
 }
 
-// gzipResponseWriter wraps http.ResponseWriter to support gzip compression
-type gzipResponseWriter struct {
-	http.ResponseWriter
-	Writer io.Writer
-}
-
 // mount sets up all the API routes
 //
 //	@Summary		Mount all API routes
@@ -79,11 +112,7 @@ func (app *application) mount() http.Handler {
 	// }))
 
 	// docsURL := fmt.Sprintf("%s/swagger/doc.json", app.config.addr)
-	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/" && r.URL.Path != "/docs" {
-			http.NotFound(w, r)
-			return
-		}
+	docs := func(w http.ResponseWriter, r *http.Request) {
 		htmlContent, err := scalar.ApiReferenceHTML(&scalar.Options{
 			SpecURL: "./docs/swagger.json",
 			CustomOptions: scalar.CustomOptions{
@@ -98,36 +127,67 @@ func (app *application) mount() http.Handler {
 		}
 
 		fmt.Fprintln(w, htmlContent)
-	})
+	}
+	mux.HandleFunc("GET /{$}", docs)
+	mux.HandleFunc("GET /docs", docs)
+
+	schema, err := app.newGraphQLSchema()
+	if err != nil {
+		app.logger.Error("error building GraphQL schema", "error", err)
+		os.Exit(1)
+	}
 
 	mux.HandleFunc("GET /health", app.healthCheckHandler)
 	mux.HandleFunc("POST /update", app.manualUpdateHandler)
 
-	mux.HandleFunc("GET /v1/rest/outlines", app.getCourseOutlines)
-	mux.HandleFunc("GET /v1/rest/prerequisites", app.getPrerequisites)
-	mux.HandleFunc("GET /v1/rest/sections", app.getSections)
-	mux.HandleFunc("GET /v1/rest/instructors", app.getInstructors)
-	mux.HandleFunc("GET /v1/rest/reviews/instructors", app.getAllInstructorReviews)
-	mux.HandleFunc("GET /v1/rest/reviews/instructors/{instructor_name}", app.getInstructorReviews)
-	mux.HandleFunc("GET /v1/rest/reviews/courses", app.getAllCourseReviews)
-	mux.HandleFunc("GET /v1/rest/reviews/courses/{course_code}", app.getCourseReviews)
-
-	return mux
-}
-
-// Write implements the io.Writer interface for gzipResponseWriter
-func (g *gzipResponseWriter) Write(b []byte) (int, error) {
-	return g.Writer.Write(b)
+	// Stores each cached route is built from, for Last-Modified
+	outlines := app.store.Outlines.LastLoaded
+	sections := app.store.Sections.LastLoaded
+	instructors := app.store.Instructors.LastLoaded
+	identities := app.store.Identities.LastLoaded
+	reviews := app.store.Reviews.LastLoaded
+
+	mux.HandleFunc("GET /v1/rest/outlines", app.cached(app.getCourseOutlines, outlines))
+	mux.HandleFunc("GET /v1/rest/search", app.cached(app.searchCourses, outlines))
+	mux.HandleFunc("GET /v1/rest/prerequisites", app.cached(app.getPrerequisites, outlines))
+	mux.HandleFunc("GET /v1/rest/sections", app.cached(app.getSections, sections, reviews))
+	mux.HandleFunc("GET /v1/rest/instructors", app.cached(app.getInstructors, instructors))
+	mux.HandleFunc("GET /v1/rest/instructors/search", app.cached(app.searchInstructors, instructors))
+	mux.HandleFunc("GET /v1/rest/instructors/{name}/profile", app.cached(app.getInstructorProfile, instructors, identities, sections, reviews))
+	mux.HandleFunc("GET /v1/rest/instructors/{name}/stats", app.cached(app.getInstructorStats, instructors, identities, sections))
+	mux.HandleFunc("GET /v1/rest/graph", app.cached(app.getTeachingGraph, sections))
+	mux.HandleFunc("GET /v1/rest/graph/instructors/{name}", app.cached(app.getInstructorGraph, instructors, identities, sections))
+	mux.HandleFunc("GET /v1/rest/graph/courses/{dept}/{number}", app.cached(app.getCourseGraph, sections))
+	mux.HandleFunc("GET /v1/rest/graph/team-taught", app.cached(app.getTeamTaughtCourses, sections))
+	mux.HandleFunc("GET /v1/rest/reviews/instructors", app.cached(app.getAllInstructorReviews, reviews))
+	mux.HandleFunc("GET /v1/rest/reviews/instructors/{instructor_name}", app.cached(app.getInstructorReviews, identities, reviews))
+	mux.HandleFunc("GET /v1/rest/reviews/instructors/{instructor_name}/stats", app.cached(app.getInstructorReviewStats, identities, reviews))
+	mux.HandleFunc("GET /v1/rest/reviews/instructors/{instructor_name}/trend", app.cached(app.getInstructorReviewTrend, identities, reviews))
+	mux.HandleFunc("GET /v1/rest/reviews/courses", app.cached(app.getAllCourseReviews, reviews))
+	mux.HandleFunc("GET /v1/rest/reviews/courses/{course_code}", app.cached(app.getCourseReviews, reviews))
+	mux.HandleFunc("GET /v1/rest/reviews/courses/{course_code}/stats", app.cached(app.getCourseReviewStats, reviews))
+	mux.HandleFunc("GET /v1/rest/reviews/quarantine", app.cached(app.getQuarantinedCourseCodes, reviews))
+	mux.HandleFunc("GET /v1/rest/analytics/timetable", app.cached(app.getTimetableHeatmap, sections))
+	mux.HandleFunc("GET /v2/rest/reviews/instructors", app.cached(app.getAllInstructorReviewsV2, reviews))
+	mux.HandleFunc("GET /v2/rest/reviews/instructors/{instructor_name}", app.cached(app.getInstructorReviewsV2, identities, reviews))
+	mux.HandleFunc("GET /v2/rest/reviews/courses", app.cached(app.getAllCourseReviewsV2, reviews))
+	mux.HandleFunc("GET /v2/rest/reviews/courses/{course_code}", app.cached(app.getCourseReviewsV2, reviews))
+	mux.HandleFunc("GET /graphql", app.cached(app.graphQLHandler(schema), outlines, sections, instructors, identities, reviews))
+	mux.HandleFunc("POST /graphql", app.graphQLHandler(schema))
+
+	mux.Handle("GET /metrics", promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+
+	return app.instrument(mux, app.rateLimit(mux))
 }
 
 // middleware applies common middleware to all requests
 //
-//	@Description	Apply common middleware including CORS, logging, timeout, and gzip compression
+//	@Description	Apply common middleware including CORS, logging, timeout, and compression
 func (app *application) middleware(next http.Handler) http.Handler {
-	return http.TimeoutHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	return app.logRequests(http.TimeoutHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if rec := recover(); rec != nil {
-				log.Printf("Recovered from panic: %v", rec)
+				app.requestLogger(r).Error("recovered from panic", "panic", rec)
 				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 			}
 		}()
@@ -135,33 +195,50 @@ func (app *application) middleware(next http.Handler) http.Handler {
 		// CORS headers
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, If-None-Match, If-Modified-Since, X-API-Key, X-Request-ID")
+		w.Header().Set("Access-Control-Expose-Headers", "ETag, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After, X-Request-ID")
 		w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
 
-		log.Printf("%s %s %s", r.Method, r.URL.Path, r.RemoteAddr)
-
 		// Handle CORS preflight requests
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusNoContent)
 			return
 		}
 
-		if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") && r.URL.Query().Get("gzip") == "true" {
-			w.Header().Set("Content-Encoding", "gzip")
-			gz := gzip.NewWriter(w)
-			defer gz.Close()
+		compress(next).ServeHTTP(w, r)
+	}), 60*time.Second, "Request timed out"))
+}
 
-			gzipWriter := &gzipResponseWriter{ResponseWriter: w, Writer: gz}
-			next.ServeHTTP(gzipWriter, r)
-			return
-		}
+// beginSync registers a data sync for shutdown to wait for, unless shutdown
+// has already started.
+func (app *application) beginSync() bool {
+	app.syncMu.Lock()
+	defer app.syncMu.Unlock()
+	if app.syncsStopped {
+		return false
+	}
+	app.syncs.Add(1)
+	return true
+}
 
-		next.ServeHTTP(w, r)
-	}), 60*time.Second, "Request timed out")
+// stopSyncs keeps new data syncs from starting.
+func (app *application) stopSyncs() {
+	app.syncMu.Lock()
+	app.syncsStopped = true
+	app.syncMu.Unlock()
 }
 
-func (app *application) runDataSync() (int, int) {
-	log.Printf("Starting data sync at %v", time.Now().UTC())
+// runDataSync runs the sync commands and reloads the stores. Canceling ctx
+// stops the running command and skips the rest of the sync. It returns
+// errSyncsStopped without syncing once shutdown has started.
+func (app *application) runDataSync(ctx context.Context) (int, int, error) {
+	if !app.beginSync() {
+		app.logger.Warn("data sync skipped for shutdown")
+		return 0, 0, errSyncsStopped
+	}
+	defer app.syncs.Done()
+
+	app.logger.Info("starting data sync")
 
 	year, term := getCurrentTerm()
 	nextTermYear, nextTermTerm := getNextTerm()
@@ -175,6 +252,7 @@ func (app *application) runDataSync() (int, int) {
 		{"fetch-sections", []string{nextTermYear, nextTermTerm}},
 		{"sync-offerings", []string{}},
 		{"sync-instructors", []string{}},
+		{"sync-aliases", []string{}},
 		{"fetch-instructors", []string{}},
 	}
 
@@ -182,41 +260,65 @@ func (app *application) runDataSync() (int, int) {
 	totalCommands := len(commands)
 
 	for _, cmdInfo := range commands {
-		log.Printf("Running %s...", cmdInfo.name)
+		if ctx.Err() != nil {
+			app.logger.Warn("data sync canceled", "succeeded", successCount, "commands", totalCommands)
+			return successCount, totalCommands, nil
+		}
+		app.logger.Info("running sync command", "command", cmdInfo.name, "args", cmdInfo.args)
 
-		cmd := exec.Command(fmt.Sprintf("./bin/%s", cmdInfo.name), cmdInfo.args...)
+		cmd := exec.CommandContext(ctx, fmt.Sprintf("./bin/%s", cmdInfo.name), cmdInfo.args...)
+		cmd.Cancel = func() error { return cmd.Process.Signal(syscall.SIGTERM) }
+		cmd.WaitDelay = syncKillDelay
 
 		// Capture both stdout and stderr
+		start := time.Now()
 		output, err := cmd.CombinedOutput()
+		metrics.ObserveSyncStep(cmdInfo.name, time.Since(start), err)
 		if err != nil {
-			log.Printf("Error running %s: %v\nOutput: %s", cmdInfo.name, err, output)
+			app.logger.Error("sync command failed", "command", cmdInfo.name, "error", err, "output", string(output))
 			continue // Continue with next command even if one fails
 		}
 
-		log.Printf("Successfully completed %s\nOutput: %s", cmdInfo.name, output)
+		app.logger.Info("sync command completed", "command", cmdInfo.name, "output", string(output))
 		successCount++
 	}
-
-	if err := app.store.Outlines.ForceReload(); err != nil {
-		log.Printf("Error reloading outlines: %v", err)
+	if ctx.Err() != nil {
+		app.logger.Warn("data sync canceled", "succeeded", successCount, "commands", totalCommands)
+		return successCount, totalCommands, nil
 	}
-	if err := app.store.Sections.ForceReload(); err != nil {
-		log.Printf("Error reloading sections: %v", err)
+
+	reloaded := true
+	stores := []struct {
+		name  string
+		store interface{ ForceReload() error }
+	}{
+		{"identities", app.store.Identities},
+		{"outlines", app.store.Outlines},
+		{"sections", app.store.Sections},
+		{"instructors", app.store.Instructors},
+		{"reviews", app.store.Reviews},
 	}
-	if err := app.store.Instructors.ForceReload(); err != nil {
-		log.Printf("Error reloading instructors: %v", err)
+	for _, s := range stores {
+		if err := s.store.ForceReload(); err != nil {
+			app.logger.Error("error reloading store", "store", s.name, "error", err)
+			reloaded = false
+		}
 	}
 
 	// trigger client ssg revalidation
 	app.triggerRevalidation("revalidate-explore")
 	app.triggerRevalidation("revalidate-schedule")
 
-	app.lastDataUpdateLock.Lock()
-	app.lastDataUpdate = time.Now().UTC()
-	app.lastDataUpdateLock.Unlock()
+	// A partial sync leaves some data stale, so the data only counts as
+	// updated once every command succeeded and every store reloaded.
+	if successCount == totalCommands && reloaded {
+		app.lastDataUpdateLock.Lock()
+		app.lastDataUpdate = time.Now().UTC()
+		app.lastDataUpdateLock.Unlock()
+	}
 
-	log.Printf("Completed data sync at %v", app.lastDataUpdate)
-	return successCount, totalCommands
+	app.logger.Info("completed data sync", "succeeded", successCount, "commands", totalCommands)
+	return successCount, totalCommands, nil
 }
 
 // manualUpdateHandler triggers a manual data update
@@ -243,39 +345,47 @@ func (app *application) manualUpdateHandler(w http.ResponseWriter, r *http.Reque
 	}
 
 	if string(body) != expectedPassword {
-		print(string(body), expectedPassword)
+		app.requestLogger(r).Warn("unauthorized update attempt")
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	successCount, totalCommands := app.runDataSync()
+	// Not the request's context, which the timeout handler cancels long before
+	// a sync is done
+	successCount, totalCommands, err := app.runDataSync(app.syncCtx)
+	if errors.Is(err, errSyncsStopped) {
+		http.Error(w, "Server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
 
 	// Return response
 	response := map[string]interface{}{
 		"status":        "completed",
 		"successCount":  successCount,
 		"totalCommands": totalCommands,
-		"lastUpdate":    app.lastDataUpdate.Format(time.RFC3339),
+		"lastUpdate":    app.dataUpdatedAt().Format(time.RFC3339),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
-// startCronJobs initializes and starts all cron jobs
-func (app *application) startCronJobs() {
+// startCronJobs initializes and starts all cron jobs. Stopping the returned
+// scheduler waits for running jobs.
+func (app *application) startCronJobs() *gocron.Scheduler {
 	s := gocron.NewScheduler(time.UTC)
 
 	_, err := s.Every(1).Hours().At("00:00").Do(func() {
-		app.runDataSync()
+		app.runDataSync(app.syncCtx)
 	})
 
 	if err != nil {
-		log.Printf("Error scheduling cron job: %v", err)
-		return
+		app.logger.Error("error scheduling cron job", "error", err)
+		return s
 	}
 
 	s.StartAsync()
+	return s
 }
 
 // getCurrentTerm returns the current academic year and term
@@ -320,12 +430,20 @@ func getNextTerm() (string, string) {
 	return getTermForDate(nextDate)
 }
 
-// run starts the HTTP server
+// run starts the HTTP server and serves until SIGINT or SIGTERM, then shuts
+// down gracefully
 //
 //	@Description	Start the HTTP server with the provided handler and configuration
 func (app *application) run(mux http.Handler) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	syncCtx, cancelSyncs := context.WithCancel(context.Background())
+	defer cancelSyncs()
+	app.syncCtx = syncCtx
+
 	// Start cron jobs
-	app.startCronJobs()
+	scheduler := app.startCronJobs()
 
 	srv := &http.Server{
 		Addr:         app.config.addr,
@@ -335,9 +453,41 @@ func (app *application) run(mux http.Handler) error {
 		IdleTimeout:  time.Minute,
 	}
 
-	log.Printf("Server has started at %s", srv.Addr)
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+	app.logger.Info("server has started", "addr", srv.Addr)
+
+	select {
+	case err := <-serveErr:
+		cancelSyncs()
+		scheduler.Stop()
+		return err
+	case <-ctx.Done():
+	}
+	// A second signal kills the server right away
+	stop()
+	app.stopSyncs()
+	app.logger.Info("shutting down", "timeout", shutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	// Running syncs get until the deadline to finish before their command is
+	// stopped. A stopped script leaves the previous data in place, as the sync
+	// scripts write their files atomically.
+	context.AfterFunc(shutdownCtx, cancelSyncs)
+
+	err := srv.Shutdown(shutdownCtx)
+	scheduler.Stop()
+	app.syncs.Wait() // manual syncs whose request outlived the deadline
+	if err != nil {
+		return fmt.Errorf("shutting down: %w", err)
+	}
 
-	return srv.ListenAndServe()
+	app.logger.Info("server stopped")
+	return nil
 }
 
 // HealthResponse represents the health check response
@@ -393,22 +543,22 @@ type ErrorResponse struct {
 func (app *application) triggerRevalidation(revalidationType string) {
 	revalidateSecret := os.Getenv("REVALIDATE_SECRET")
 	if revalidateSecret == "" {
-		log.Printf("REVALIDATE_SECRET not set, skipping revalidation call")
+		app.logger.Info("REVALIDATE_SECRET not set, skipping revalidation call")
 		return
 	}
 	url := "https://sfucourses.com/api/revalidate?secret=" + revalidateSecret + "&type=" + revalidationType
 	client := &http.Client{Timeout: 10 * time.Second}
 	req, err := http.NewRequest("POST", url, nil)
 	if err != nil {
-		log.Printf("Error creating revalidate request: %v", err)
+		app.logger.Error("error creating revalidate request", "type", revalidationType, "error", err)
 		return
 	}
 	resp, err := client.Do(req)
 	if err != nil {
-		log.Printf("Error calling revalidate endpoint: %v", err)
+		app.logger.Error("error calling revalidate endpoint", "type", revalidationType, "error", err)
 		return
 	}
 	defer resp.Body.Close()
 	body, _ := io.ReadAll(resp.Body)
-	log.Printf("Revalidate response: %s", string(body))
+	app.logger.Info("revalidate response", "type", revalidationType, "status", resp.StatusCode, "body", string(body))
 }
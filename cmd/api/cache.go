@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// cacheControl lets clients and CDNs reuse responses for a few minutes, then
+// revalidate them. The data changes at most hourly through runDataSync.
+const cacheControl = "public, max-age=300, stale-while-revalidate=3600"
+
+// bufferedResponseWriter holds back a response so it can be validated
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponseWriter) WriteHeader(status int) {
+	b.status = status
+}
+
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+// cached serves successful responses with an ETag of their body, the time the
+// stores they're built from were last loaded as Last-Modified, and
+// Cache-Control, answering conditional requests with 304 Not Modified.
+//
+// The ETag is weak since compression may change the bytes sent.
+func (app *application) cached(next http.HandlerFunc, lastLoaded ...func() time.Time) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		buffered := &bufferedResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next(buffered, r)
+
+		if buffered.status != http.StatusOK {
+			w.WriteHeader(buffered.status)
+			w.Write(buffered.body.Bytes())
+			return
+		}
+
+		// Stores may have reloaded while handling the request
+		var lastModified time.Time
+		for _, loaded := range lastLoaded {
+			if t := loaded(); t.After(lastModified) {
+				lastModified = t
+			}
+		}
+		lastModified = lastModified.UTC().Truncate(time.Second)
+
+		hash := fnv.New64a()
+		hash.Write(buffered.body.Bytes())
+		etag := fmt.Sprintf(`W/"%016x"`, hash.Sum64())
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", cacheControl)
+		if !lastModified.IsZero() {
+			w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+		}
+
+		if notModified(r, etag, lastModified) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(buffered.body.Bytes())
+	}
+}
+
+// notModified reports whether the client's cached copy of a response is
+// current. If-None-Match takes precedence over If-Modified-Since, as ETags
+// change only when the body does while stores reload on a schedule.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince := r.Header.Get("If-Modified-Since"); ifModifiedSince != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		return err == nil && !lastModified.After(since)
+	}
+	return false
+}
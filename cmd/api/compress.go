@@ -0,0 +1,198 @@
+package main
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// minCompressSize is the smallest body worth compressing; below it, the
+// encoding overhead outweighs the savings.
+const minCompressSize = 1024
+
+// encodings lists the supported content codings, most preferred first
+var encodings = []string{"zstd", "br", "gzip"}
+
+// compressibleTypes lists the media types worth compressing
+var compressibleTypes = []string{"application/json", "application/javascript", "application/xml", "image/svg+xml", "text/"}
+
+// encoder is a compressor that can be reused for another response
+type encoder interface {
+	io.WriteCloser
+	Reset(io.Writer)
+}
+
+// encoderPools reuse encoders by content coding, as zstd ones are costly to create
+var encoderPools = map[string]*sync.Pool{
+	"gzip": {New: func() any {
+		return gzip.NewWriter(io.Discard)
+	}},
+	"br": {New: func() any {
+		return brotli.NewWriterLevel(io.Discard, 4)
+	}},
+	"zstd": {New: func() any {
+		encoder, _ := zstd.NewWriter(io.Discard, zstd.WithEncoderConcurrency(1))
+		return encoder
+	}},
+}
+
+// negotiateEncoding picks the preferred content coding accepted by an
+// Accept-Encoding header, or "" for none.
+func negotiateEncoding(acceptEncoding string) string {
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+		if coding == "x-gzip" {
+			coding = "gzip"
+		}
+
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, found := strings.Cut(param, "=")
+			if found && strings.EqualFold(strings.TrimSpace(name), "q") {
+				var err error
+				if quality, err = strconv.ParseFloat(strings.TrimSpace(value), 64); err != nil {
+					quality = 0
+				}
+			}
+		}
+		qualities[coding] = quality
+	}
+
+	best, bestQuality := "", 0.0
+	for _, coding := range encodings {
+		quality, found := qualities[coding]
+		if !found {
+			quality = qualities["*"]
+		}
+		if quality > bestQuality {
+			best, bestQuality = coding, quality
+		}
+	}
+	return best
+}
+
+// compressResponseWriter compresses a response with a content coding once it
+// reaches minCompressSize, holding back the status and headers until then.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding string
+	status   int
+	buf      []byte
+	encoder  encoder
+	started  bool
+}
+
+func (c *compressResponseWriter) WriteHeader(status int) {
+	if c.status == 0 {
+		c.status = status
+	}
+}
+
+func (c *compressResponseWriter) Write(p []byte) (int, error) {
+	if c.started {
+		if c.encoder != nil {
+			return c.encoder.Write(p)
+		}
+		return c.ResponseWriter.Write(p)
+	}
+
+	c.buf = append(c.buf, p...)
+	if len(c.buf) >= minCompressSize {
+		if err := c.start(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// start writes the status and headers, compressing the rest of the response
+// if asked and the response allows it.
+func (c *compressResponseWriter) start(compress bool) error {
+	c.started = true
+	if c.status == 0 {
+		c.status = http.StatusOK
+	}
+
+	header := c.Header()
+	if header.Get("Content-Type") == "" && len(c.buf) > 0 {
+		header.Set("Content-Type", http.DetectContentType(c.buf))
+	}
+
+	if compress && c.compressible() {
+		header.Set("Content-Encoding", c.encoding)
+		header.Del("Content-Length")
+		c.encoder = encoderPools[c.encoding].Get().(encoder)
+		c.encoder.Reset(c.ResponseWriter)
+	}
+
+	c.ResponseWriter.WriteHeader(c.status)
+	buf := c.buf
+	c.buf = nil
+	_, err := c.Write(buf)
+	return err
+}
+
+// compressible reports whether the response can be compressed.
+func (c *compressResponseWriter) compressible() bool {
+	if c.status == http.StatusNoContent || c.status == http.StatusNotModified || c.status == http.StatusPartialContent {
+		return false
+	}
+
+	header := c.Header()
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	for _, compressible := range compressibleTypes {
+		if strings.HasPrefix(contentType, compressible) {
+			return true
+		}
+	}
+	return false
+}
+
+// Close writes the rest of the response, uncompressed if it's too small.
+func (c *compressResponseWriter) Close() error {
+	if !c.started {
+		return c.start(false)
+	}
+	if c.encoder == nil {
+		return nil
+	}
+
+	err := c.encoder.Close()
+	c.encoder.Reset(io.Discard)
+	encoderPools[c.encoding].Put(c.encoder)
+	c.encoder = nil
+	return err
+}
+
+// compress compresses responses with the content coding preferred by the
+// client's Accept-Encoding header.
+func compress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressResponseWriter{ResponseWriter: w, encoding: encoding}
+		defer cw.Close()
+		next.ServeHTTP(cw, r)
+	})
+}
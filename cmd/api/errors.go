@@ -1,21 +1,26 @@
 package main
 
 import (
-	"log"
 	"net/http"
 )
 
 func (app *application) internalServerError(w http.ResponseWriter, r *http.Request, err error) {
-	log.Printf("internal server error: %s path: %s error: %s", r.Method, r.URL.Path, err.Error())
+	app.requestLogger(r).Error("internal server error", "error", err)
 	writeJSONError(w, http.StatusInternalServerError, "the server encountered a problem")
 }
 
 func (app *application) badRequestResponse(w http.ResponseWriter, r *http.Request, err error) {
-	log.Printf("bad request error: %s path: %s error: %s", r.Method, r.URL.Path, err)
+	app.requestLogger(r).Warn("bad request error", "error", err)
 	writeJSONError(w, http.StatusBadRequest, err.Error())
 }
 
 func (app *application) notFoundResponse(w http.ResponseWriter, r *http.Request, err error) {
-	log.Printf("not found error: %s path: %s error: %s", r.Method, r.URL.Path, err)
+	app.requestLogger(r).Info("not found error", "error", err)
 	writeJSONError(w, http.StatusNotFound, "resource not found")
 }
+
+func (app *application) rateLimitExceededResponse(w http.ResponseWriter, r *http.Request, retryAfter string) {
+	app.requestLogger(r).Warn("rate limit exceeded", "retry_after", retryAfter)
+	w.Header().Set("Retry-After", retryAfter)
+	writeJSONError(w, http.StatusTooManyRequests, "rate limit exceeded, retry in "+retryAfter+" seconds")
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/brianrahadi/sfucourses-api/internal/graph"
+	"github.com/brianrahadi/sfucourses-api/internal/store"
+)
+
+// @Summary		Get teaching graph
+// @Description	Returns the graph of instructors and courses across all loaded terms. Instructors are connected to the courses they taught ("taught") and to the instructors they shared a section with ("co-taught"). Edges are weighted by number of terms.
+// @Tags			Graph
+// @Accept			json
+// @Produce		json
+// @Param			dept	query		string				false	"Department code to limit the graph to (e.g., cmpt, math)"
+// @Success		200		{object}	model.TeachingGraph	"Teaching graph"
+// @Failure		500		{object}	ErrorResponse		"Internal server error"
+// @Router			/v1/rest/graph [get]
+func (app *application) getTeachingGraph(w http.ResponseWriter, r *http.Request) {
+	dept := r.URL.Query().Get("dept")
+
+	teachingGraph, err := app.store.Sections.GetTeachingGraph(r.Context())
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, teachingGraph.All(dept)); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+}
+
+// @Summary		Get instructor neighbourhood
+// @Description	Returns an instructor with the courses they taught and the instructors they co-taught with. With depth=2, the other instructors who taught the same courses are included.
+// @Tags			Graph
+// @Accept			json
+// @Produce		json
+// @Param			name	path		string				true	"Instructor ID or name (e.g., alin, Angela_Lin, Angela Lin)"
+// @Param			depth	query		int					false	"1 for direct neighbours (default), 2 to include other instructors of the same courses"
+// @Success		200		{object}	model.TeachingGraph	"Instructor neighbourhood"
+// @Failure		400		{object}	ErrorResponse		"Invalid instructor name or depth"
+// @Failure		404		{object}	ErrorResponse		"Instructor not found"
+// @Failure		500		{object}	ErrorResponse		"Internal server error"
+// @Router			/v1/rest/graph/instructors/{name} [get]
+func (app *application) getInstructorGraph(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := strings.TrimSpace(r.PathValue("name"))
+	if name == "" {
+		app.badRequestResponse(w, r, errors.New("instructor name is required"))
+		return
+	}
+
+	depth := 1
+	switch r.URL.Query().Get("depth") {
+	case "", "1":
+	case "2":
+		depth = 2
+	default:
+		app.badRequestResponse(w, r, errors.New("depth must be 1 or 2"))
+		return
+	}
+
+	instructor, err := app.findInstructor(ctx, name)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	teachingGraph, err := app.store.Sections.GetTeachingGraph(r.Context())
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+	nodeID := graph.InstructorNodeID(instructor.ID, instructor.Name)
+	if !teachingGraph.HasNode(nodeID) {
+		app.notFoundResponse(w, r, store.ErrNotFound)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, teachingGraph.InstructorNeighbors(nodeID, depth)); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+}
+
+// @Summary		Get course neighbourhood
+// @Description	Returns a course with every instructor who taught it, and the co-taught edges between those instructors for that course
+// @Tags			Graph
+// @Accept			json
+// @Produce		json
+// @Param			dept	path		string				true	"Department code (e.g., cmpt, math)"
+// @Param			number	path		string				true	"Course number (e.g., 120, 225)"
+// @Success		200		{object}	model.TeachingGraph	"Course neighbourhood"
+// @Failure		404		{object}	ErrorResponse		"Course not found"
+// @Failure		500		{object}	ErrorResponse		"Internal server error"
+// @Router			/v1/rest/graph/courses/{dept}/{number} [get]
+func (app *application) getCourseGraph(w http.ResponseWriter, r *http.Request) {
+	dept := r.PathValue("dept")
+	number := r.PathValue("number")
+
+	teachingGraph, err := app.store.Sections.GetTeachingGraph(r.Context())
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+	nodeID := graph.CourseNodeID(dept, number)
+	if !teachingGraph.HasNode(nodeID) {
+		app.notFoundResponse(w, r, store.ErrNotFound)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, teachingGraph.CourseNeighbors(nodeID)); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+}
+
+// @Summary		Get team-taught courses
+// @Description	Returns the courses with at least one section taught by more than one instructor
+// @Tags			Graph
+// @Accept			json
+// @Produce		json
+// @Param			dept	query		string					false	"Department code (e.g., cmpt, math)"
+// @Success		200		{array}		model.TeamTaughtCourse	"Team-taught courses"
+// @Failure		500		{object}	ErrorResponse			"Internal server error"
+// @Router			/v1/rest/graph/team-taught [get]
+func (app *application) getTeamTaughtCourses(w http.ResponseWriter, r *http.Request) {
+	dept := r.URL.Query().Get("dept")
+
+	teachingGraph, err := app.store.Sections.GetTeachingGraph(r.Context())
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, teachingGraph.TeamTaught(dept)); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,898 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/brianrahadi/sfucourses-api/internal/reviews"
+	"github.com/brianrahadi/sfucourses-api/internal/search"
+	"github.com/brianrahadi/sfucourses-api/internal/store"
+	"github.com/brianrahadi/sfucourses-api/internal/utils"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/graphql-go/graphql/language/visitor"
+	"github.com/samber/lo"
+)
+
+const (
+	// maxGraphQLDepth is the deepest nesting of fields a query may select.
+	maxGraphQLDepth = 15
+	// maxGraphQLCost is the most fields a query may be estimated to resolve.
+	maxGraphQLCost = 50000
+	// maxGraphQLListSize is the most items a list field returns.
+	maxGraphQLListSize = 100
+	// graphQLListCost is the estimated number of items of lists without a limit.
+	graphQLListCost = 10
+)
+
+// graphQLRequest is the body of a GraphQL request
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+	Extensions    map[string]any `json:"extensions"`
+}
+
+// offering is a term offering of a course
+type offering struct {
+	Course      model.CourseOutline
+	Term        string
+	Instructors []string
+}
+
+// section is a section of a course, which scopes instructor ratings to the course
+type section struct {
+	model.SectionDetail
+	CourseCode string
+}
+
+// instructor is an instructor reached from anywhere in the graph. CourseCode
+// is set when reached through a course, to scope its ratings to that course.
+type instructor struct {
+	ID         string
+	Name       string
+	Email      string
+	CourseCode string
+}
+
+// nameOrID returns the most precise way to look up the instructor.
+func (i instructor) nameOrID() string {
+	if i.ID != "" {
+		return i.ID
+	}
+	return i.Name
+}
+
+// resolve returns a resolver reading a value from the source of a field.
+func resolve[T any](fn func(T) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		return fn(p.Source.(T)), nil
+	}
+}
+
+// internalGraphQLError logs an unexpected error and hides it from the client.
+func (app *application) internalGraphQLError(p graphql.ResolveParams, err error) error {
+	app.logger.Error("internal server error", "request_id", requestID(p.Context), "graphql_field", p.Info.FieldName, "error", err)
+	return errors.New("the server encountered a problem")
+}
+
+// reviewArgs are the arguments of review lists.
+var reviewArgs = graphql.FieldConfigArgument{
+	"course": &graphql.ArgumentConfig{Type: graphql.String, Description: "Only reviews of this course code (e.g., CMPT225)"},
+	"sort": &graphql.ArgumentConfig{Type: graphql.NewEnum(graphql.EnumConfig{
+		Name: "ReviewSort",
+		Values: graphql.EnumValueConfigMap{
+			"DATE":       &graphql.EnumValueConfig{Value: reviews.SortDate},
+			"HELPFUL":    &graphql.EnumValueConfig{Value: reviews.SortHelpful},
+			"RATING":     &graphql.EnumValueConfig{Value: reviews.SortRating},
+			"DIFFICULTY": &graphql.EnumValueConfig{Value: reviews.SortDifficulty},
+		},
+	}), Description: "Sort key (default: file order)"},
+	"ascending": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false, Description: "Sort in ascending order"},
+	"limit":     &graphql.ArgumentConfig{Type: graphql.Int, Description: fmt.Sprintf("Maximum number of reviews (1-%d, default %d)", maxReviewLimit, maxGraphQLListSize)},
+	"offset":    &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0, Description: "Number of matching reviews to skip"},
+}
+
+// reviewPage applies the arguments of a review list to reviews.
+func reviewPage(p graphql.ResolveParams, all []model.Review) (any, error) {
+	q := reviews.Query{}
+	q.CourseCode, _ = p.Args["course"].(string)
+	q.Sort, _ = p.Args["sort"].(string)
+	q.Ascending, _ = p.Args["ascending"].(bool)
+	q.Offset, _ = p.Args["offset"].(int)
+	if limit, ok := p.Args["limit"].(int); ok {
+		if limit < 1 || limit > maxReviewLimit {
+			return nil, fmt.Errorf("limit must be between 1 and %d", maxReviewLimit)
+		}
+		q.Limit = limit
+	}
+	if q.Offset < 0 {
+		return nil, errors.New("offset must be a non-negative integer")
+	}
+
+	page, _ := reviews.Apply(all, q)
+	return reviews.ReviewsV2(page), nil
+}
+
+// newGraphQLSchema builds the GraphQL schema, which links courses to their
+// offerings, sections, instructors and reviews.
+func (app *application) newGraphQLSchema() (graphql.Schema, error) {
+	keywordType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Keyword",
+		Description: "Distinctive phrase of reviews",
+		Fields: graphql.Fields{
+			"phrase": &graphql.Field{Type: graphql.String},
+			"count":  &graphql.Field{Type: graphql.Int, Description: "Number of reviews mentioning the phrase"},
+			"score":  &graphql.Field{Type: graphql.Float, Description: "TF-IDF score; higher is more distinctive"},
+		},
+	})
+
+	reviewType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Review",
+		Description: "Review from RateMyProfessors; values missing from RateMyProfessors are null",
+		Fields: graphql.Fields{
+			"rating":              &graphql.Field{Type: graphql.Float},
+			"difficulty":          &graphql.Field{Type: graphql.Float},
+			"courseCode":          &graphql.Field{Type: graphql.String, Description: "Course code as entered by the reviewer"},
+			"date":                &graphql.Field{Type: graphql.String, Description: "Review date in YYYY-MM-DD format"},
+			"forCredit":           &graphql.Field{Type: graphql.Boolean},
+			"attendanceMandatory": &graphql.Field{Type: graphql.Boolean},
+			"wouldTakeAgain":      &graphql.Field{Type: graphql.Boolean},
+			"textbook":            &graphql.Field{Type: graphql.Boolean},
+			"onlineClass":         &graphql.Field{Type: graphql.Boolean},
+			"grade":               &graphql.Field{Type: graphql.String},
+			"reviewMsg":           &graphql.Field{Type: graphql.String},
+			"helpful":             &graphql.Field{Type: graphql.Int},
+			"notHelpful":          &graphql.Field{Type: graphql.Int},
+			"tags":                &graphql.Field{Type: graphql.NewList(graphql.String)},
+		},
+	})
+
+	prereqType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Prerequisite",
+		Description: "Node of a prerequisite expression tree",
+		Fields:      graphql.Fields{},
+	})
+	prereqType.AddFieldConfig("type", &graphql.Field{Type: graphql.String, Description: "Node type: course, and, or"})
+	prereqType.AddFieldConfig("id", &graphql.Field{Type: graphql.String, Description: "Course code or special token, for type course"})
+	prereqType.AddFieldConfig("children", &graphql.Field{Type: graphql.NewList(prereqType), Description: "Child nodes, for types and and or"})
+
+	scheduleType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Schedule",
+		Fields: graphql.Fields{
+			"startDate":   &graphql.Field{Type: graphql.String},
+			"endDate":     &graphql.Field{Type: graphql.String},
+			"campus":      &graphql.Field{Type: graphql.String},
+			"days":        &graphql.Field{Type: graphql.String},
+			"startTime":   &graphql.Field{Type: graphql.String},
+			"endTime":     &graphql.Field{Type: graphql.String},
+			"sectionCode": &graphql.Field{Type: graphql.String, Description: "Section code type (LEC, TUT, LAB)"},
+		},
+	})
+
+	ratingsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "InstructorRatings",
+		Description: "Instructor review summary; values missing from RateMyProfessors are null",
+		Fields: graphql.Fields{
+			"avgRating":           &graphql.Field{Type: graphql.Float},
+			"avgDifficulty":       &graphql.Field{Type: graphql.Float},
+			"wouldTakeAgain":      &graphql.Field{Type: graphql.Float, Description: "Percentage who would take again"},
+			"reviewCount":         &graphql.Field{Type: graphql.Int},
+			"courseAvgRating":     &graphql.Field{Type: graphql.Float, Description: "Average rating in reviews of the course"},
+			"courseAvgDifficulty": &graphql.Field{Type: graphql.Float, Description: "Average difficulty in reviews of the course"},
+			"courseReviewCount":   &graphql.Field{Type: graphql.Int, Description: "Number of reviews of the course"},
+		},
+	})
+
+	instructorReviewsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "InstructorReviews",
+		Description: "Reviews of an instructor from RateMyProfessors",
+		Fields: graphql.Fields{
+			"professorId":   &graphql.Field{Type: graphql.String, Description: "RateMyProfessors professor ID"},
+			"professorName": &graphql.Field{Type: graphql.String},
+			"department":    &graphql.Field{Type: graphql.String},
+			"overallRating": &graphql.Field{Type: graphql.Float, Resolve: resolve(func(data model.InstructorReviewData) any {
+				return reviews.ParseFloat(data.OverallRating)
+			})},
+			"wouldTakeAgain": &graphql.Field{Type: graphql.Float, Resolve: resolve(func(data model.InstructorReviewData) any {
+				return reviews.ParseFloat(data.WouldTakeAgain)
+			})},
+			"difficultyLevel": &graphql.Field{Type: graphql.Float, Resolve: resolve(func(data model.InstructorReviewData) any {
+				return reviews.ParseFloat(data.DifficultyLevel)
+			})},
+			"totalRatings": &graphql.Field{Type: graphql.Int, Resolve: resolve(func(data model.InstructorReviewData) any {
+				return reviews.ParseInt(data.TotalRatings)
+			})},
+			"keywords": &graphql.Field{Type: graphql.NewList(keywordType)},
+			"reviews": &graphql.Field{
+				Type: graphql.NewList(reviewType),
+				Args: reviewArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return reviewPage(p, p.Source.(model.InstructorReviewData).Reviews)
+				},
+			},
+		},
+	})
+
+	courseType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Course",
+		Description: "Course outline",
+		Fields:      graphql.Fields{},
+	})
+
+	instructorType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Instructor",
+		Description: "Instructor, linked to their course offerings and reviews",
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.String, Description: "Stable instructor ID, if known"},
+			"name":  &graphql.Field{Type: graphql.String},
+			"email": &graphql.Field{Type: graphql.String, Description: "Email address, if reached through a section"},
+			"ratings": &graphql.Field{
+				Type:        ratingsType,
+				Description: "Review summary, with the averages of reviews of the course the instructor was reached through",
+				Args: graphql.FieldConfigArgument{
+					"course": &graphql.ArgumentConfig{Type: graphql.String, Description: "Course code to average reviews of instead (e.g., CMPT225)"},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					source := p.Source.(instructor)
+					if source.ID == "" {
+						return nil, nil
+					}
+					courseCode := source.CourseCode
+					if course, ok := p.Args["course"].(string); ok {
+						courseCode = course
+					}
+
+					ratings, err := app.store.Reviews.GetInstructorRatings(p.Context, source.ID, courseCode)
+					switch {
+					case errors.Is(err, store.ErrNotFound):
+						return nil, nil
+					case err != nil:
+						return nil, app.internalGraphQLError(p, err)
+					}
+					return ratings, nil
+				},
+			},
+			"reviews": &graphql.Field{
+				Type: instructorReviewsType,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					reviewData, err := app.loadInstructorReviewData(p.Context, p.Source.(instructor).nameOrID())
+					switch {
+					case errors.Is(err, store.ErrNotFound):
+						return nil, nil
+					case err != nil:
+						return nil, app.internalGraphQLError(p, err)
+					}
+					return reviewData, nil
+				},
+			},
+		},
+	})
+
+	instructorOfferingType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "InstructorOffering",
+		Description: "Course offering taught by an instructor",
+		Fields: graphql.Fields{
+			"dept":   &graphql.Field{Type: graphql.String},
+			"number": &graphql.Field{Type: graphql.String},
+			"title":  &graphql.Field{Type: graphql.String},
+			"term":   &graphql.Field{Type: graphql.String, Description: "Academic term (e.g., Fall 2024)"},
+			"termCode": &graphql.Field{Type: graphql.String, Description: "Academic term code (e.g., 2024-fall)", Resolve: resolve(func(offering model.InstructorOffering) any {
+				return utils.TermCodeFromTerm(offering.Term)
+			})},
+			"course": &graphql.Field{
+				Type: courseType,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					offering := p.Source.(model.InstructorOffering)
+					return app.resolveCourse(p, offering.Dept, offering.Number)
+				},
+			},
+		},
+	})
+	instructorType.AddFieldConfig("aliases", &graphql.Field{
+		Type:        graphql.NewList(graphql.String),
+		Description: "Other names the instructor appears under",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			found, err := app.resolveInstructor(p)
+			return found.Aliases, err
+		},
+	})
+	instructorType.AddFieldConfig("offerings", &graphql.Field{
+		Type:        graphql.NewList(instructorOfferingType),
+		Description: "Course offerings taught by the instructor",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			found, err := app.resolveInstructor(p)
+			return found.Offerings, err
+		},
+	})
+
+	sectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Section",
+		Description: "Section of a course offering",
+		Fields: graphql.Fields{
+			"section": &graphql.Field{Type: graphql.String, Description: "Section code (e.g., D100)", Resolve: resolve(func(s section) any {
+				return s.Section
+			})},
+			"deliveryMethod": &graphql.Field{Type: graphql.String, Resolve: resolve(func(s section) any {
+				return s.DeliveryMethod
+			})},
+			"classNumber": &graphql.Field{Type: graphql.String, Description: "Class number for registration", Resolve: resolve(func(s section) any {
+				return s.ClassNumber
+			})},
+			"schedules": &graphql.Field{Type: graphql.NewList(scheduleType), Resolve: resolve(func(s section) any {
+				return s.Schedules
+			})},
+			"instructors": &graphql.Field{Type: graphql.NewList(instructorType), Resolve: resolve(func(s section) any {
+				return lo.Map(s.Instructors, func(i model.Instructor, _ int) instructor {
+					return instructor{ID: i.ID, Name: i.Name, Email: i.Email, CourseCode: s.CourseCode}
+				})
+			})},
+		},
+	})
+
+	offeringType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Offering",
+		Description: "Term offering of a course",
+		Fields: graphql.Fields{
+			"term": &graphql.Field{Type: graphql.String, Description: "Academic term (e.g., Fall 2024)"},
+			"termCode": &graphql.Field{Type: graphql.String, Description: "Academic term code (e.g., 2024-fall)", Resolve: resolve(func(o offering) any {
+				return utils.TermCodeFromTerm(o.Term)
+			})},
+			"instructors": &graphql.Field{Type: graphql.NewList(instructorType), Resolve: resolve(func(o offering) any {
+				return lo.Map(o.Instructors, func(name string, _ int) instructor {
+					return instructor{ID: app.store.Identities.Resolve(name, ""), Name: name, CourseCode: o.Course.Dept + o.Course.Number}
+				})
+			})},
+			"sections": &graphql.Field{
+				Type: graphql.NewList(sectionType),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					o := p.Source.(offering)
+					year, term, err := splitYearTerm(utils.TermCodeFromTerm(o.Term))
+					if err != nil {
+						return []section{}, nil
+					}
+
+					courses, err := app.store.Sections.Get(p.Context, year, term, o.Course.Dept, o.Course.Number)
+					switch {
+					case errors.Is(err, store.ErrNotFound):
+						return []section{}, nil
+					case err != nil:
+						return nil, app.internalGraphQLError(p, err)
+					}
+
+					sections := []section{}
+					for _, course := range courses {
+						for _, detail := range course.SectionDetails {
+							sections = append(sections, section{SectionDetail: detail, CourseCode: course.Dept + course.Number})
+						}
+					}
+					return sections, nil
+				},
+			},
+		},
+	})
+
+	courseInstructorReviewsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "CourseInstructorReviews",
+		Description: "Reviews of a course by instructor",
+		Fields: graphql.Fields{
+			"instructor": &graphql.Field{Type: instructorType, Resolve: resolve(func(summary model.InstructorSummary) any {
+				return instructor{ID: summary.InstructorID, Name: summary.ProfessorName}
+			})},
+			"professorId":   &graphql.Field{Type: graphql.String, Description: "RateMyProfessors professor ID"},
+			"professorName": &graphql.Field{Type: graphql.String},
+			"department":    &graphql.Field{Type: graphql.String},
+			"avgRating":     &graphql.Field{Type: graphql.Float},
+			"avgDifficulty": &graphql.Field{Type: graphql.Float},
+			"reviewCount":   &graphql.Field{Type: graphql.Int},
+			"wouldTakeAgain": &graphql.Field{Type: graphql.Float, Description: "Percentage who would take again", Resolve: resolve(func(summary model.InstructorSummary) any {
+				return reviews.ParseFloat(summary.WouldTakeAgain)
+			})},
+			"reviews": &graphql.Field{
+				Type: graphql.NewList(reviewType),
+				Args: reviewArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return reviewPage(p, p.Source.(model.InstructorSummary).Reviews)
+				},
+			},
+		},
+	})
+
+	courseReviewsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "CourseReviews",
+		Description: "Reviews of a course, aggregated from its instructors",
+		Fields: graphql.Fields{
+			"courseCode":   &graphql.Field{Type: graphql.String},
+			"totalReviews": &graphql.Field{Type: graphql.Int},
+			"avgRating": &graphql.Field{Type: graphql.Float, Resolve: resolve(func(data model.CourseReviewData) any {
+				return lo.EmptyableToPtr(data.AvgRating)
+			})},
+			"avgDifficulty": &graphql.Field{Type: graphql.Float, Resolve: resolve(func(data model.CourseReviewData) any {
+				return lo.EmptyableToPtr(data.AvgDifficulty)
+			})},
+			"keywords":    &graphql.Field{Type: graphql.NewList(keywordType)},
+			"instructors": &graphql.Field{Type: graphql.NewList(courseInstructorReviewsType)},
+		},
+	})
+
+	courseFields := graphql.Fields{
+		"dept":           &graphql.Field{Type: graphql.String, Description: "Department code (e.g., CMPT)"},
+		"number":         &graphql.Field{Type: graphql.String, Description: "Course number (e.g., 225)"},
+		"title":          &graphql.Field{Type: graphql.String},
+		"units":          &graphql.Field{Type: graphql.String},
+		"description":    &graphql.Field{Type: graphql.String},
+		"notes":          &graphql.Field{Type: graphql.String},
+		"designation":    &graphql.Field{Type: graphql.String},
+		"deliveryMethod": &graphql.Field{Type: graphql.String},
+		"prerequisites":  &graphql.Field{Type: graphql.String},
+		"corequisites":   &graphql.Field{Type: graphql.String},
+		"degreeLevel":    &graphql.Field{Type: graphql.String},
+		"parsedPrerequisites": &graphql.Field{Type: prereqType, Resolve: resolve(func(outline model.CourseOutline) any {
+			if node, found := app.store.Outlines.GetPrereqMap()[outline.Dept+" "+outline.Number]; found {
+				return node
+			}
+			return nil
+		})},
+		"offerings": &graphql.Field{
+			Type: graphql.NewList(offeringType),
+			Args: graphql.FieldConfigArgument{
+				"term": &graphql.ArgumentConfig{Type: graphql.String, Description: "Only the offering in this term (e.g., 2024-fall)"},
+			},
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				outline := p.Source.(model.CourseOutline)
+				term, _ := p.Args["term"].(string)
+
+				offerings := []offering{}
+				for _, o := range outline.Offerings {
+					if term == "" || strings.EqualFold(utils.TermCodeFromTerm(o.Term), term) {
+						offerings = append(offerings, offering{Course: outline, Term: o.Term, Instructors: o.Instructors})
+					}
+				}
+				return offerings, nil
+			},
+		},
+		"reviews": &graphql.Field{
+			Type: courseReviewsType,
+			Resolve: func(p graphql.ResolveParams) (any, error) {
+				outline := p.Source.(model.CourseOutline)
+				courseData, err := app.store.Reviews.GetCourse(p.Context, outline.Dept+outline.Number)
+				switch {
+				case errors.Is(err, store.ErrNotFound):
+					return nil, nil
+				case err != nil:
+					return nil, app.internalGraphQLError(p, err)
+				}
+				return courseData, nil
+			},
+		},
+	}
+	for name, field := range courseFields {
+		courseType.AddFieldConfig(name, field)
+	}
+
+	searchResultType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "CourseSearchResult",
+		Description: "Course matching a search query",
+		Fields: graphql.Fields{
+			"score":         &graphql.Field{Type: graphql.Float, Description: "Relevance score, higher is better"},
+			"matchedFields": &graphql.Field{Type: graphql.NewList(graphql.String)},
+			"course": &graphql.Field{
+				Type: courseType,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					result := p.Source.(model.CourseSearchResult)
+					return app.resolveCourse(p, result.Dept, result.Number)
+				},
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"course": &graphql.Field{
+				Type:        courseType,
+				Description: "Course by department and number",
+				Args: graphql.FieldConfigArgument{
+					"dept":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String), Description: "Department code (e.g., cmpt)"},
+					"number": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String), Description: "Course number (e.g., 225)"},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return app.resolveCourse(p, p.Args["dept"].(string), p.Args["number"].(string))
+				},
+			},
+			"courses": &graphql.Field{
+				Type:        graphql.NewList(courseType),
+				Description: "Page of courses, optionally in one department",
+				Args: graphql.FieldConfigArgument{
+					"dept":   &graphql.ArgumentConfig{Type: graphql.String, Description: "Department code (e.g., cmpt)"},
+					"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: maxGraphQLListSize, Description: fmt.Sprintf("Page size (1-%d)", maxGraphQLListSize)},
+					"offset": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0, Description: "Number of courses to skip"},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					dept, _ := p.Args["dept"].(string)
+					limit, offset := p.Args["limit"].(int), p.Args["offset"].(int)
+					if limit < 1 || limit > maxGraphQLListSize {
+						return nil, fmt.Errorf("limit must be between 1 and %d", maxGraphQLListSize)
+					}
+					if offset < 0 {
+						return nil, errors.New("offset must be a non-negative integer")
+					}
+
+					outlines, err := app.store.Outlines.Get(p.Context, dept, "")
+					switch {
+					case errors.Is(err, store.ErrNotFound):
+						return []model.CourseOutline{}, nil
+					case err != nil:
+						return nil, app.internalGraphQLError(p, err)
+					}
+					start := min(offset, len(outlines))
+					return outlines[start:min(start+limit, len(outlines))], nil
+				},
+			},
+			"search": &graphql.Field{
+				Type:        graphql.NewList(searchResultType),
+				Description: "Courses matching a full-text query, most relevant first",
+				Args: graphql.FieldConfigArgument{
+					"q":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String), Description: "Words and quoted phrases to match"},
+					"dept":  &graphql.ArgumentConfig{Type: graphql.String, Description: "Only courses in this department (e.g., cmpt)"},
+					"level": &graphql.ArgumentConfig{Type: graphql.Int, Description: "Only courses at this level (e.g., 300)"},
+					"term":  &graphql.ArgumentConfig{Type: graphql.String, Description: "Only courses offered in this term (e.g., 2024-fall)"},
+					"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultSearchLimit, Description: fmt.Sprintf("Maximum number of results (1-%d)", maxGraphQLListSize)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					query := search.Query{}
+					query.Text, _ = p.Args["q"].(string)
+					query.Dept, _ = p.Args["dept"].(string)
+					query.Level, _ = p.Args["level"].(int)
+					query.Term, _ = p.Args["term"].(string)
+					limit := p.Args["limit"].(int)
+					if limit < 1 || limit > maxGraphQLListSize {
+						return nil, fmt.Errorf("limit must be between 1 and %d", maxGraphQLListSize)
+					}
+
+					results, err := app.store.Outlines.Search(p.Context, query)
+					if err != nil {
+						return nil, app.internalGraphQLError(p, err)
+					}
+					return results[:min(limit, len(results))], nil
+				},
+			},
+			"instructor": &graphql.Field{
+				Type:        instructorType,
+				Description: "Instructor by ID or name",
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String), Description: "Instructor ID or name (e.g., alin, Angela Lin)"},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					found, err := app.loadInstructor(p.Context, p.Args["name"].(string))
+					switch {
+					case errors.Is(err, store.ErrNotFound):
+						return nil, nil
+					case err != nil:
+						return nil, app.internalGraphQLError(p, err)
+					}
+					return instructor{ID: found.ID, Name: found.Name}, nil
+				},
+			},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+	if err != nil {
+		return schema, err
+	}
+	limitResolvers(schema)
+	return schema, nil
+}
+
+// limitResolvers wraps the resolvers of the schema's fields to stop resolving
+// once the request is done, and to cap lists at maxGraphQLListSize items.
+func limitResolvers(schema graphql.Schema) {
+	for name, schemaType := range schema.TypeMap() {
+		object, ok := schemaType.(*graphql.Object)
+		if !ok || strings.HasPrefix(name, "__") {
+			continue
+		}
+
+		for _, field := range object.Fields() {
+			next := field.Resolve
+			if next == nil {
+				next = graphql.DefaultResolveFn
+			}
+			fieldType := field.Type
+			if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+				fieldType = nonNull.OfType
+			}
+			_, isList := fieldType.(*graphql.List)
+
+			field.Resolve = func(p graphql.ResolveParams) (any, error) {
+				if err := p.Context.Err(); err != nil {
+					return nil, err
+				}
+				value, err := next(p)
+				if list := reflect.ValueOf(value); isList && list.Kind() == reflect.Slice && list.Len() > maxGraphQLListSize {
+					value = list.Slice(0, maxGraphQLListSize).Interface()
+				}
+				return value, err
+			}
+		}
+	}
+}
+
+// graphQLRules are the rules queries are validated against before execution.
+var graphQLRules = append(slices.Clone(graphql.SpecifiedRules), limitQueryComplexity)
+
+// limitQueryComplexity rejects queries nested deeper than maxGraphQLDepth
+// fields or estimated to resolve more than maxGraphQLCost fields, since the
+// cycles of the schema let a short query fan out over the whole dataset.
+func limitQueryComplexity(ctx *graphql.ValidationContext) *graphql.ValidationRuleInstance {
+	return &graphql.ValidationRuleInstance{
+		VisitorOpts: &visitor.VisitorOptions{
+			KindFuncMap: map[string]visitor.NamedVisitFuncs{
+				kinds.OperationDefinition: {
+					Kind: func(p visitor.VisitFuncParams) (string, any) {
+						operation, ok := p.Node.(*ast.OperationDefinition)
+						if !ok || operation.Operation != ast.OperationTypeQuery {
+							return visitor.ActionNoChange, nil
+						}
+
+						depth, cost := selectionComplexity(ctx, ctx.Schema().QueryType(), operation.SelectionSet, 1, map[string]bool{})
+						switch {
+						case depth > maxGraphQLDepth:
+							ctx.ReportError(gqlerrors.NewError(fmt.Sprintf("query must not be nested deeper than %d fields", maxGraphQLDepth), []ast.Node{operation}, "", nil, []int{}, nil))
+						case cost > maxGraphQLCost:
+							ctx.ReportError(gqlerrors.NewError(fmt.Sprintf("query must not resolve more than an estimated %d fields", maxGraphQLCost), []ast.Node{operation}, "", nil, []int{}, nil))
+						}
+						return visitor.ActionNoChange, nil
+					},
+				},
+			},
+		},
+	}
+}
+
+// selectionComplexity returns the depth of a selection set at a level of a
+// query and its estimated cost, stopping early once either is over its limit.
+// Fragments already being expanded are skipped, as their cycles are reported
+// by another rule.
+func selectionComplexity(ctx *graphql.ValidationContext, parent *graphql.Object, set *ast.SelectionSet, level int, spreads map[string]bool) (depth, cost int) {
+	if set == nil {
+		return 0, 0
+	}
+	if level > maxGraphQLDepth {
+		return 1, 0
+	}
+
+	for _, selection := range set.Selections {
+		var selectionDepth, selectionCost int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			selectionDepth, selectionCost = fieldComplexity(ctx, parent, selection, level, spreads)
+		case *ast.InlineFragment:
+			selectionDepth, selectionCost = selectionComplexity(ctx, fragmentType(ctx, parent, selection.TypeCondition), selection.SelectionSet, level, spreads)
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment := ctx.Fragment(name)
+			if fragment == nil || spreads[name] {
+				continue
+			}
+			spreads[name] = true
+			selectionDepth, selectionCost = selectionComplexity(ctx, fragmentType(ctx, parent, fragment.TypeCondition), fragment.SelectionSet, level, spreads)
+			delete(spreads, name)
+		}
+
+		depth = max(depth, selectionDepth)
+		if cost += selectionCost; cost > maxGraphQLCost {
+			break
+		}
+	}
+	return depth, min(cost, maxGraphQLCost+1)
+}
+
+// fieldComplexity returns the depth and estimated cost of a field. A field
+// costs one, plus the cost of its selection once per estimated list item.
+func fieldComplexity(ctx *graphql.ValidationContext, parent *graphql.Object, field *ast.Field, level int, spreads map[string]bool) (depth, cost int) {
+	var definition *graphql.FieldDefinition
+	switch name := field.Name.Value; {
+	case name == graphql.SchemaMetaFieldDef.Name:
+		definition = graphql.SchemaMetaFieldDef
+	case name == graphql.TypeMetaFieldDef.Name:
+		definition = graphql.TypeMetaFieldDef
+	case parent != nil:
+		definition = parent.Fields()[name]
+	}
+	if definition == nil {
+		return 1, 1
+	}
+
+	fieldType, items := definition.Type, 1
+	for unwrapped := false; !unwrapped; {
+		switch t := fieldType.(type) {
+		case *graphql.NonNull:
+			fieldType = t.OfType
+		case *graphql.List:
+			fieldType, items = t.OfType, items*listSize(definition, field)
+		default:
+			unwrapped = true
+		}
+	}
+
+	object, _ := fieldType.(*graphql.Object)
+	depth, cost = selectionComplexity(ctx, object, field.SelectionSet, level+1, spreads)
+	return 1 + depth, 1 + items*cost
+}
+
+// listSize estimates the number of items of a list field from its limit
+// argument, assuming the largest list if the limit is a variable.
+func listSize(definition *graphql.FieldDefinition, field *ast.Field) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		if value, ok := arg.Value.(*ast.IntValue); ok {
+			if limit, err := strconv.Atoi(value.Value); err == nil {
+				return min(max(limit, 1), maxGraphQLListSize)
+			}
+		}
+		return maxGraphQLListSize
+	}
+
+	for _, arg := range definition.Args {
+		if limit, ok := arg.DefaultValue.(int); ok && arg.Name() == "limit" {
+			return min(limit, maxGraphQLListSize)
+		}
+	}
+	return graphQLListCost
+}
+
+// fragmentType returns the type a fragment applies to, defaulting to parent.
+func fragmentType(ctx *graphql.ValidationContext, parent *graphql.Object, condition *ast.Named) *graphql.Object {
+	if condition == nil {
+		return parent
+	}
+	if object, ok := ctx.Schema().Type(condition.Name.Value).(*graphql.Object); ok {
+		return object
+	}
+	return parent
+}
+
+// resolveCourse resolves a course by its code, or null if there's no outline.
+func (app *application) resolveCourse(p graphql.ResolveParams, dept, number string) (any, error) {
+	outlines, err := app.store.Outlines.Get(p.Context, dept, number)
+	switch {
+	case errors.Is(err, store.ErrNotFound) || (err == nil && len(outlines) == 0):
+		return nil, nil
+	case err != nil:
+		return nil, app.internalGraphQLError(p, err)
+	}
+	return outlines[0], nil
+}
+
+type instructorLoaderKey struct{}
+
+// instructorLoader memoizes the instructor lookups of a GraphQL request, as
+// every field of an instructor resolves it again.
+type instructorLoader struct {
+	mu    sync.Mutex
+	found map[string]instructorLookup
+}
+
+// instructorLookup is the result of looking up an instructor.
+type instructorLookup struct {
+	instructor model.InstructorResponse
+	err        error
+}
+
+// withInstructorLoader returns a context memoizing instructor lookups.
+func withInstructorLoader(ctx context.Context) context.Context {
+	return context.WithValue(ctx, instructorLoaderKey{}, &instructorLoader{found: map[string]instructorLookup{}})
+}
+
+// loadInstructor finds an instructor by ID or name, reusing earlier lookups
+// of the request.
+func (app *application) loadInstructor(ctx context.Context, nameOrID string) (model.InstructorResponse, error) {
+	loader, ok := ctx.Value(instructorLoaderKey{}).(*instructorLoader)
+	if !ok {
+		return app.findInstructor(ctx, nameOrID)
+	}
+
+	loader.mu.Lock()
+	defer loader.mu.Unlock()
+	lookup, found := loader.found[nameOrID]
+	if !found {
+		lookup.instructor, lookup.err = app.findInstructor(ctx, nameOrID)
+		loader.found[nameOrID] = lookup
+	}
+	return lookup.instructor, lookup.err
+}
+
+// resolveInstructor looks up the offerings of the instructor of a field,
+// which are empty if the instructor has taught no known offerings.
+func (app *application) resolveInstructor(p graphql.ResolveParams) (model.InstructorResponse, error) {
+	found, err := app.loadInstructor(p.Context, p.Source.(instructor).nameOrID())
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return model.InstructorResponse{Offerings: []model.InstructorOffering{}}, nil
+	case err != nil:
+		return model.InstructorResponse{}, app.internalGraphQLError(p, err)
+	}
+	return found, nil
+}
+
+// @Summary		Query with GraphQL
+// @Description	Executes a GraphQL query over outlines, sections, instructors and reviews, linking courses to their offerings, sections, instructors and reviews, so clients fetch exactly the nested data they need in one round trip. The root fields are course, courses, search and instructor. Queries are sent as a JSON body with query, variables and operationName, or as query parameters on GET. Errors of individual fields are returned in the errors array of a 200 response. Queries nested deeper than 15 fields or estimated to resolve more than 50000 fields are rejected before execution, and lists return at most 100 items.
+// @Tags			GraphQL
+// @Accept			json
+// @Produce		json
+// @Param			request			body		graphQLRequest	false	"GraphQL request, for POST"
+// @Param			query			query		string			false	"GraphQL query, for GET"
+// @Param			variables		query		string			false	"JSON object of variables, for GET"
+// @Param			operationName	query		string			false	"Operation to execute, if the query has several, for GET"
+// @Success		200				{object}	map[string]any	"Query result, with data and errors"
+// @Failure		400				{object}	ErrorResponse	"Missing query or invalid request body"
+// @Router			/graphql [get]
+// @Router			/graphql [post]
+func (app *application) graphQLHandler(schema graphql.Schema) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLRequest
+		if r.Method == http.MethodGet {
+			params := r.URL.Query()
+			req.Query = params.Get("query")
+			req.OperationName = params.Get("operationName")
+			if variables := params.Get("variables"); variables != "" {
+				if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+					app.badRequestResponse(w, r, errors.New("variables must be a JSON object"))
+					return
+				}
+			}
+		} else if err := readJSON(w, r, &req); err != nil {
+			app.badRequestResponse(w, r, err)
+			return
+		}
+
+		if strings.TrimSpace(req.Query) == "" {
+			app.badRequestResponse(w, r, errors.New("query is required"))
+			return
+		}
+
+		document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})})
+		if err != nil {
+			if err := writeJSON(w, http.StatusOK, &graphql.Result{Errors: gqlerrors.FormatErrors(err)}); err != nil {
+				app.internalServerError(w, r, err)
+			}
+			return
+		}
+		if validation := graphql.ValidateDocument(&schema, document, graphQLRules); !validation.IsValid {
+			if err := writeJSON(w, http.StatusOK, &graphql.Result{Errors: validation.Errors}); err != nil {
+				app.internalServerError(w, r, err)
+			}
+			return
+		}
+
+		result := graphql.Execute(graphql.ExecuteParams{
+			Schema:        schema,
+			AST:           document,
+			OperationName: req.OperationName,
+			Args:          req.Variables,
+			Context:       withInstructorLoader(r.Context()),
+		})
+
+		if err := writeJSON(w, http.StatusOK, result); err != nil {
+			app.internalServerError(w, r, err)
+			return
+		}
+	}
+}
@@ -24,9 +24,7 @@ var BuildTime string = time.Now().Format(time.RFC3339)
 // @Failure		500	{object}	ErrorResponse	"Internal Server Error"
 // @Router			/health [get]
 func (app *application) healthCheckHandler(w http.ResponseWriter, r *http.Request) {
-	app.lastDataUpdateLock.RLock()
-	lastUpdate := app.lastDataUpdate
-	app.lastDataUpdateLock.RUnlock()
+	lastUpdate := app.dataUpdatedAt()
 
 	resp := HealthResponse{
 		Status:         "ok",
@@ -42,3 +40,16 @@ func (app *application) healthCheckHandler(w http.ResponseWriter, r *http.Reques
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
+
+// dataUpdatedAt returns when every sync command last succeeded, or when the
+// API was built if no sync has fully succeeded since starting.
+func (app *application) dataUpdatedAt() time.Time {
+	app.lastDataUpdateLock.RLock()
+	lastUpdate := app.lastDataUpdate
+	app.lastDataUpdateLock.RUnlock()
+
+	if lastUpdate.IsZero() {
+		lastUpdate, _ = time.Parse(time.RFC3339, BuildTime)
+	}
+	return lastUpdate
+}
@@ -1,23 +1,36 @@
 package main
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/brianrahadi/sfucourses-api/internal/analytics"
+	"github.com/brianrahadi/sfucourses-api/internal/identity"
+	"github.com/brianrahadi/sfucourses-api/internal/model"
 	"github.com/brianrahadi/sfucourses-api/internal/store"
+	"github.com/brianrahadi/sfucourses-api/internal/utils"
+	"github.com/samber/lo"
 )
 
 // @Summary		Get instructors
-// @Description	Retrieves instructors with optional filtering by department, course number, or name
+// @Description	Retrieves instructors with optional filtering by department, course number, or name. With limit or offset, returns one page of instructors in a paginated envelope instead of the full list.
 // @Tags			Instructors
 // @Accept			json
 // @Produce		json
-// @Param			dept	query		string						false	"Department code (e.g., cmpt, math)"
-// @Param			number	query		string						false	"Course number (e.g., 120, 225)"
-// @Param			name	query		string						false	"Instructor name (URL encoded)"
-// @Success		200		{array}		model.InstructorResponse	"List of instructors"
-// @Failure		404		{object}	ErrorResponse				"No instructors found"
-// @Failure		500		{object}	ErrorResponse				"Internal server error"
+// @Param			dept	query		string												false	"Department code (e.g., cmpt, math)"
+// @Param			number	query		string												false	"Course number (e.g., 120, 225)"
+// @Param			name	query		string												false	"Instructor name (URL encoded)"
+// @Param			limit	query		int													false	"Page size (1-1000, default 100 when offset is set)"
+// @Param			offset	query		int													false	"Number of instructors to skip (default 0)"
+// @Success		200		{object}	model.PaginatedResponse[model.InstructorResponse]	"Page of instructors, if limit or offset is set"
+// @Success		200		{array}		model.InstructorResponse							"List of instructors"
+// @Failure		400		{object}	ErrorResponse										"Invalid limit or offset"
+// @Failure		404		{object}	ErrorResponse										"No instructors found"
+// @Failure		500		{object}	ErrorResponse										"Internal server error"
 // @Router			/v1/rest/instructors [get]
 func (app *application) getInstructors(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -25,6 +38,12 @@ func (app *application) getInstructors(w http.ResponseWriter, r *http.Request) {
 	number := r.URL.Query().Get("number")
 	name := r.URL.Query().Get("name")
 
+	page, paginated, err := parsePageQuery(r.URL.Query())
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
 	instructors, err := app.store.Instructors.Get(ctx, dept, number, name)
 	if err != nil {
 		switch {
@@ -36,8 +55,286 @@ func (app *application) getInstructors(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := writeJSON(w, http.StatusOK, instructors); err != nil {
+	if err := writeList(w, r, instructors, page, paginated); err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
 }
+
+const (
+	defaultInstructorSearchLimit = 10
+	maxInstructorSearchLimit     = 100
+)
+
+// @Summary		Search instructors
+// @Description	Searches instructors by name or alias, ranked by relevance. Matching ignores case, accents and word order, and tolerates small typos.
+// @Tags			Instructors
+// @Accept			json
+// @Produce		json
+// @Param			q		query		string							true	"Search query (e.g., muller, cote isabelle)"
+// @Param			limit	query		int								false	"Maximum number of results (default 10, max 100)"
+// @Success		200		{array}		model.InstructorSearchResult	"Matching instructors, best match first"
+// @Failure		400		{object}	ErrorResponse					"Missing query or invalid limit"
+// @Failure		500		{object}	ErrorResponse					"Internal server error"
+// @Router			/v1/rest/instructors/search [get]
+func (app *application) searchInstructors(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		app.badRequestResponse(w, r, errors.New("q parameter is required"))
+		return
+	}
+
+	limit := defaultInstructorSearchLimit
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed < 1 || parsed > maxInstructorSearchLimit {
+			app.badRequestResponse(w, r, fmt.Errorf("limit must be between 1 and %d", maxInstructorSearchLimit))
+			return
+		}
+		limit = parsed
+	}
+
+	results, err := app.store.Instructors.Search(ctx, query, limit)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, results); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+}
+
+// findInstructor returns the instructor with the given ID, or whose name or alias
+// matches exactly, ignoring case.
+// Returns store.ErrNotFound if there is no such instructor.
+func (app *application) findInstructor(ctx context.Context, nameOrID string) (model.InstructorResponse, error) {
+	id, name := "", nameOrID
+	instructorIdentity, err := app.store.Identities.Lookup(ctx, nameOrID)
+	switch {
+	case err == nil:
+		id, name = instructorIdentity.ID, instructorIdentity.Name
+	case !errors.Is(err, store.ErrNotFound):
+		return model.InstructorResponse{}, err
+	}
+
+	instructors, err := app.store.Instructors.Get(ctx, "", "", strings.ReplaceAll(name, "_", " "))
+	if err != nil {
+		return model.InstructorResponse{}, err
+	}
+
+	for _, instructor := range instructors {
+		if id != "" && instructor.ID == id {
+			return instructor, nil
+		}
+	}
+
+	normalized := identity.NormalizeName(nameOrID)
+	for _, instructor := range instructors {
+		if identity.NormalizeName(instructor.Name) == normalized || lo.ContainsBy(instructor.Aliases, func(alias string) bool {
+			return identity.NormalizeName(alias) == normalized
+		}) {
+			return instructor, nil
+		}
+	}
+
+	return model.InstructorResponse{}, store.ErrNotFound
+}
+
+// @Summary		Get instructor profile
+// @Description	Retrieves an instructor's teaching history, current-term sections with schedules, email, and review summary in a single response
+// @Tags			Instructors
+// @Accept			json
+// @Produce		json
+// @Param			name	path		string					true	"Instructor ID or name (e.g., alin, Angela_Lin, Angela Lin)"
+// @Param			term	query		string					false	"Year and term used for current sections in format YYYY-Term (defaults to the current term)"
+// @Success		200		{object}	model.InstructorProfile	"Instructor profile"
+// @Failure		400		{object}	ErrorResponse			"Invalid instructor name or year-term format"
+// @Failure		404		{object}	ErrorResponse			"Instructor not found"
+// @Failure		500		{object}	ErrorResponse			"Internal server error"
+// @Router			/v1/rest/instructors/{name}/profile [get]
+func (app *application) getInstructorProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := strings.TrimSpace(r.PathValue("name"))
+	if name == "" {
+		app.badRequestResponse(w, r, errors.New("instructor name is required"))
+		return
+	}
+
+	year, term := getCurrentTerm()
+	if yearTerm := r.URL.Query().Get("term"); yearTerm != "" {
+		var err error
+		year, term, err = splitYearTerm(yearTerm)
+		if err != nil {
+			app.badRequestResponse(w, r, err)
+			return
+		}
+	}
+	currentTermCode := year + "-" + strings.ToLower(term)
+
+	instructor, err := app.findInstructor(ctx, name)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	profile := model.InstructorProfile{
+		ID:              instructor.ID,
+		Name:            instructor.Name,
+		Aliases:         instructor.Aliases,
+		CurrentTerm:     utils.TermFromTermCode(currentTermCode),
+		CurrentSections: []model.InstructorSection{},
+		TeachingHistory: []model.InstructorTermHistory{},
+	}
+
+	for _, offering := range instructor.Offerings {
+		if n := len(profile.TeachingHistory); n == 0 || profile.TeachingHistory[n-1].Term != offering.Term {
+			profile.TeachingHistory = append(profile.TeachingHistory, model.InstructorTermHistory{Term: offering.Term})
+		}
+		history := &profile.TeachingHistory[len(profile.TeachingHistory)-1]
+		history.Courses = append(history.Courses, offering)
+	}
+
+	sections, email, err := app.getInstructorSections(ctx, instructor)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+	profile.Email = email
+	for _, section := range sections {
+		if utils.TermCodeFromTerm(section.Term) == currentTermCode {
+			profile.CurrentSections = append(profile.CurrentSections, section)
+		}
+	}
+
+	reviewQuery := instructor.Name
+	if instructor.ID != "" {
+		reviewQuery = instructor.ID
+	}
+
+	reviewData, err := app.loadInstructorReviewData(ctx, reviewQuery)
+	switch {
+	case err == nil:
+		profile.Reviews = &model.InstructorReviewSummary{
+			ProfessorID:     reviewData.ProfessorID,
+			OverallRating:   reviewData.OverallRating,
+			WouldTakeAgain:  reviewData.WouldTakeAgain,
+			DifficultyLevel: reviewData.DifficultyLevel,
+			Department:      reviewData.Department,
+			TotalRatings:    reviewData.TotalRatings,
+		}
+	case !errors.Is(err, store.ErrNotFound):
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, profile); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+}
+
+// @Summary		Get instructor teaching stats
+// @Description	Returns teaching statistics of an instructor: courses taught per term, distinct courses, departments, total sections, lecture vs lab split, and the first and last term taught
+// @Tags			Instructors
+// @Accept			json
+// @Produce		json
+// @Param			name	path		string					true	"Instructor ID or name (e.g., alin, Angela_Lin, Angela Lin)"
+// @Success		200		{object}	model.InstructorStats	"Instructor teaching stats"
+// @Failure		400		{object}	ErrorResponse			"Invalid instructor name"
+// @Failure		404		{object}	ErrorResponse			"Instructor not found"
+// @Failure		500		{object}	ErrorResponse			"Internal server error"
+// @Router			/v1/rest/instructors/{name}/stats [get]
+func (app *application) getInstructorStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := strings.TrimSpace(r.PathValue("name"))
+	if name == "" {
+		app.badRequestResponse(w, r, errors.New("instructor name is required"))
+		return
+	}
+
+	instructor, err := app.findInstructor(ctx, name)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, err)
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	sections, _, err := app.getInstructorSections(ctx, instructor)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	stats := analytics.BuildInstructorStats(instructor, sections)
+
+	if err := writeJSON(w, http.StatusOK, stats); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+}
+
+// getInstructorSections returns every section the instructor taught in their
+// offerings, along with their most recently listed email address.
+func (app *application) getInstructorSections(ctx context.Context, instructor model.InstructorResponse) ([]model.InstructorSection, string, error) {
+	var sections []model.InstructorSection
+	email := ""
+
+	// Offerings are sorted most recent first, so the first email found is the latest one
+	for _, offering := range instructor.Offerings {
+		year, term, err := splitYearTerm(utils.TermCodeFromTerm(offering.Term))
+		if err != nil {
+			continue
+		}
+
+		courses, err := app.store.Sections.Get(ctx, year, term, offering.Dept, offering.Number)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				continue
+			}
+			return nil, "", err
+		}
+
+		for _, course := range courses {
+			for _, section := range course.SectionDetails {
+				sectionInstructor, found := lo.Find(section.Instructors, func(i model.Instructor) bool {
+					if instructor.ID != "" {
+						return i.ID == instructor.ID
+					}
+					return strings.EqualFold(strings.TrimSpace(i.Name), instructor.Name)
+				})
+				if !found {
+					continue
+				}
+
+				if email == "" {
+					email = sectionInstructor.Email
+				}
+
+				sections = append(sections, model.InstructorSection{
+					Dept:           course.Dept,
+					Number:         course.Number,
+					Title:          course.Title,
+					Term:           offering.Term,
+					Section:        section.Section,
+					DeliveryMethod: section.DeliveryMethod,
+					ClassNumber:    section.ClassNumber,
+					Schedules:      section.Schedules,
+				})
+			}
+		}
+	}
+
+	return sections, email, nil
+}
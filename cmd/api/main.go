@@ -1,8 +1,13 @@
 package main
 
 import (
-	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/brianrahadi/sfucourses-api/internal/metrics"
+	"github.com/brianrahadi/sfucourses-api/internal/ratelimit"
 	"github.com/brianrahadi/sfucourses-api/internal/store"
 )
 
@@ -26,20 +31,62 @@ const version = "1.0.0"
 // @tag.description				Outline endpoints for retrieving course outlines, including its offerings
 // @tag.name						Sections
 // @tag.description				Section endpoints for retrieving section info, including its schedules and instructor(s)
+// @tag.name						Analytics
+// @tag.description				Analytics endpoints for aggregated views over sections, such as timetable utilization
+// @tag.name						Graph
+// @tag.description				Graph endpoints relating instructors and the courses they taught, including co-teaching
+// @tag.name						GraphQL
+// @tag.description				GraphQL endpoint linking courses to their offerings, sections, instructors and reviews
 func main() {
 	cfg := config{
-		addr:   ":8080",
-		env:    "dev",
-		apiURL: "api.sfucourses.com",
+		addr:           ":8080",
+		env:            "dev",
+		apiURL:         "api.sfucourses.com",
+		trustedProxies: ratelimit.ParsePrefixes(os.Getenv("TRUSTED_PROXIES")),
+		rateLimit: rateLimitConfig{
+			enabled:      os.Getenv("RATE_LIMIT") != "off",
+			defaultLimit: ratelimit.Limit{Requests: 120, Period: time.Minute},
+			routeLimits: map[string]ratelimit.Limit{
+				"GET /{$}":              {},
+				"GET /health":           {},
+				"GET /metrics":          {},
+				"POST /update":          {Requests: 5, Period: time.Hour},
+				"GET /v1/rest/outlines": {Requests: 30, Period: time.Minute},
+				"GET /v1/rest/sections": {Requests: 30, Period: time.Minute},
+				"GET /v1/rest/graph":    {Requests: 30, Period: time.Minute},
+				"GET /graphql":          {Requests: 60, Period: time.Minute},
+				"POST /graphql":         {Requests: 60, Period: time.Minute},
+			},
+			apiKeys: make(map[string]bool),
+		},
 	}
+	for _, key := range strings.Split(os.Getenv("API_KEYS"), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			cfg.rateLimit.apiKeys[key] = true
+		}
+	}
+
+	// JSON logs in production for log aggregation, readable ones otherwise.
+	// The default logger sends the stores' log.Printf lines through it too.
+	var handler slog.Handler = slog.NewTextHandler(os.Stdout, nil)
+	if os.Getenv("ENV") == "production" {
+		handler = slog.NewJSONHandler(os.Stdout, nil)
+	}
+	logger := slog.New(handler)
+	slog.SetDefault(logger)
 
 	store := store.NewStorage()
 
 	app := &application{
 		config: cfg,
 		store:  store,
+		logger: logger,
 	}
+	metrics.RegisterDatasetAge(app.dataUpdatedAt)
 
 	mux := app.mount()
-	log.Fatal(app.run(mux))
+	if err := app.run(mux); err != nil {
+		logger.Error("server stopped", "error", err)
+		os.Exit(1)
+	}
 }
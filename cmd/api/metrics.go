@@ -0,0 +1,35 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/brianrahadi/sfucourses-api/internal/metrics"
+)
+
+// instrument records the metrics of requests to the routes of mux, labelled by
+// route pattern rather than path to keep the number of series bounded.
+func (app *application) instrument(mux *http.ServeMux, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		_, route := mux.Handler(r)
+		if route == "" {
+			route = "unmatched"
+		}
+
+		metrics.RequestsInFlight.Inc()
+		defer metrics.RequestsInFlight.Dec()
+
+		lw := &loggingResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(lw, r)
+		if lw.status == 0 {
+			lw.status = http.StatusOK
+		}
+
+		status := strconv.Itoa(lw.status)
+		metrics.RequestsTotal.WithLabelValues(route, status).Inc()
+		metrics.RequestDuration.WithLabelValues(route, status).Observe(time.Since(start).Seconds())
+		metrics.ResponseSize.WithLabelValues(route).Observe(float64(lw.bytes))
+	})
+}
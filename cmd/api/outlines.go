@@ -3,24 +3,30 @@ package main
 import (
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/brianrahadi/sfucourses-api/internal/search"
 	"github.com/brianrahadi/sfucourses-api/internal/store"
 )
 
 // @Summary		Get course outlines
-// @Description	Retrieves course outlines, optionally filtered by department and/or course number
+// @Description	Retrieves course outlines, optionally filtered by department and/or course number. With limit or offset, returns one page of outlines in a paginated envelope instead of the full list.
 // @Tags			Outlines
 // @Accept			json
 // @Produce		json
-// @Param			dept	query		string				false	"Department code (e.g., cmpt, math)"
-// @Param			number	query		string				false	"Course number (e.g., 120, 225)"
-// @Param			short	query		bool				false	"Return short outline with only dept, number, title, and units"
-// @Param			prereqs	query		bool				false	"Include parsed prerequisite expression trees"
-// @Success		200		{array}		model.CourseOutline	"List of course outlines"
-// @Failure		404		{object}	ErrorResponse		"No outlines found for the specified criteria"
-// @Failure		500		{object}	ErrorResponse		"Internal server error"
+// @Param			dept	query		string							false	"Department code (e.g., cmpt, math)"
+// @Param			number	query		string							false	"Course number (e.g., 120, 225)"
+// @Param			short	query		bool							false	"Return short outline with only dept, number, title, and units"
+// @Param			prereqs	query		bool							false	"Include parsed prerequisite expression trees"
+// @Param			limit	query		int								false	"Page size (1-1000, default 100 when offset is set)"
+// @Param			offset	query		int								false	"Number of outlines to skip (default 0)"
+// @Success		200		{object}	model.AllCourseOutlinesResponse	"Page of course outlines, if limit or offset is set"
+// @Success		200		{array}		model.CourseOutline				"List of course outlines"
+// @Failure		400		{object}	ErrorResponse					"Invalid limit or offset"
+// @Failure		404		{object}	ErrorResponse					"No outlines found for the specified criteria"
+// @Failure		500		{object}	ErrorResponse					"Internal server error"
 // @Router			/v1/rest/outlines [get]
 func (app *application) getCourseOutlines(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -30,6 +36,12 @@ func (app *application) getCourseOutlines(w http.ResponseWriter, r *http.Request
 	isShort := strings.ToLower(r.URL.Query().Get("short")) == "true" || strings.ToLower(r.URL.Query().Get("SHORT")) == "true"
 	includePrereqs := strings.ToLower(r.URL.Query().Get("prereqs")) == "true"
 
+	page, paginated, err := parsePageQuery(r.URL.Query())
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
 	outlines, err := app.store.Outlines.Get(ctx, dept, number)
 	if err != nil {
 		switch {
@@ -51,7 +63,7 @@ func (app *application) getCourseOutlines(w http.ResponseWriter, r *http.Request
 				Units:  o.Units,
 			})
 		}
-		writeJSON(w, http.StatusOK, shortOutlines)
+		writeList(w, r, shortOutlines, page, paginated)
 		return
 	}
 
@@ -66,9 +78,76 @@ func (app *application) getCourseOutlines(w http.ResponseWriter, r *http.Request
 			}
 			result = append(result, entry)
 		}
-		writeJSON(w, http.StatusOK, result)
+		writeList(w, r, result, page, paginated)
+		return
+	}
+
+	writeList(w, r, outlines, page, paginated)
+}
+
+const defaultSearchLimit = 20
+
+// @Summary		Search courses
+// @Description	Full-text search over course codes, titles, designations, descriptions and notes. Words are stemmed and must all match; quoted phrases must match in order. Results are ranked by relevance, with title matches above description matches.
+// @Tags			Outlines
+// @Accept			json
+// @Produce		json
+// @Param			q		query		string												true	"Search query, with phrases in double quotes (e.g., machine learning, ethics)"
+// @Param			dept	query		string												false	"Department code (e.g., cmpt, phil)"
+// @Param			level	query		int													false	"Course level (e.g., 300 for 3XX courses)"
+// @Param			term	query		string												false	"Only courses offered in a term, in format YYYY-Term (e.g., 2024-fall)"
+// @Param			limit	query		int													false	"Page size (1-1000, default 20)"
+// @Param			offset	query		int													false	"Number of results to skip (default 0)"
+// @Success		200		{object}	model.PaginatedResponse[model.CourseSearchResult]	"Matching courses, most relevant first"
+// @Failure		400		{object}	ErrorResponse										"Missing query or invalid query parameters"
+// @Failure		500		{object}	ErrorResponse										"Internal server error"
+// @Router			/v1/rest/search [get]
+func (app *application) searchCourses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := search.Query{
+		Text: strings.TrimSpace(r.URL.Query().Get("q")),
+		Dept: r.URL.Query().Get("dept"),
+	}
+	if query.Text == "" {
+		app.badRequestResponse(w, r, errors.New("q parameter is required"))
+		return
+	}
+
+	if level := r.URL.Query().Get("level"); level != "" {
+		parsed, err := strconv.Atoi(level)
+		if err != nil || parsed < 100 || parsed > 900 || parsed%100 != 0 {
+			app.badRequestResponse(w, r, errors.New("level must be one of 100, 200, ..., 900"))
+			return
+		}
+		query.Level = parsed
+	}
+
+	if yearTerm := r.URL.Query().Get("term"); yearTerm != "" {
+		year, term, err := splitYearTerm(yearTerm)
+		if err != nil {
+			app.badRequestResponse(w, r, err)
+			return
+		}
+		query.Term = year + "-" + strings.ToLower(term)
+	}
+
+	page, paginated, err := parsePageQuery(r.URL.Query())
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+	if !paginated {
+		page.limit = defaultSearchLimit
+	}
+
+	results, err := app.store.Outlines.Search(ctx, query)
+	if err != nil {
+		app.internalServerError(w, r, err)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, outlines)
+	if err := writeJSON(w, http.StatusOK, paginate(r, results, page)); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
 }
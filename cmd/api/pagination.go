@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+)
+
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
+// pageQuery is the requested page of a list endpoint.
+type pageQuery struct {
+	limit  int
+	offset int
+}
+
+// parsePageQuery parses the limit and offset query parameters. Lists are only
+// paginated if either is set, so that existing clients keep getting the full
+// list as a plain array.
+func parsePageQuery(params url.Values) (pageQuery, bool, error) {
+	page := pageQuery{limit: defaultPageLimit}
+	limit, offset := params.Get("limit"), params.Get("offset")
+	if limit == "" && offset == "" {
+		return page, false, nil
+	}
+
+	var err error
+	if limit != "" {
+		if page.limit, err = strconv.Atoi(limit); err != nil || page.limit < 1 || page.limit > maxPageLimit {
+			return page, false, fmt.Errorf("limit must be between 1 and %d", maxPageLimit)
+		}
+	}
+	if offset != "" {
+		if page.offset, err = strconv.Atoi(offset); err != nil || page.offset < 0 {
+			return page, false, errors.New("offset must be a non-negative integer")
+		}
+	}
+	return page, true, nil
+}
+
+// paginate returns one page of items, with links to the next and previous
+// pages that keep the other query parameters of the request.
+func paginate[T any](r *http.Request, items []T, page pageQuery) model.PaginatedResponse[T] {
+	total := len(items)
+	start := min(page.offset, total)
+	end := min(start+page.limit, total)
+
+	response := model.PaginatedResponse[T]{
+		Data:       items[start:end:end],
+		TotalCount: total,
+	}
+	if response.Data == nil {
+		response.Data = []T{}
+	}
+	if end < total {
+		response.NextURL = pageURL(r, page.limit, end)
+	}
+	if start > 0 {
+		response.PrevURL = pageURL(r, page.limit, max(start-page.limit, 0))
+	}
+	return response
+}
+
+// pageURL returns the request URL with another limit and offset.
+func pageURL(r *http.Request, limit, offset int) string {
+	params := r.URL.Query()
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("offset", strconv.Itoa(offset))
+	return r.URL.Path + "?" + params.Encode()
+}
+
+// writeList writes items as a plain array, or one page of them if pagination
+// was requested.
+func writeList[T any](w http.ResponseWriter, r *http.Request, items []T, page pageQuery, paginated bool) error {
+	if paginated {
+		return writeJSON(w, http.StatusOK, paginate(r, items, page))
+	}
+	return writeJSON(w, http.StatusOK, items)
+}
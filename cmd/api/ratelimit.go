@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/brianrahadi/sfucourses-api/internal/ratelimit"
+)
+
+// apiKeyLimitFactor scales the limits of clients with an API key, which are
+// usually servers making requests on behalf of many users.
+const apiKeyLimitFactor = 10
+
+// rateLimit limits the requests of each client to each route with a token
+// bucket, sending the RateLimit headers of the IETF draft. Clients are keyed
+// by their API key if it's a known one, or by their IP address otherwise.
+func (app *application) rateLimit(mux *http.ServeMux) http.Handler {
+	cfg := app.config.rateLimit
+	if !cfg.enabled {
+		return mux
+	}
+	limiter := ratelimit.NewLimiter()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := mux.Handler(r)
+		limit, found := cfg.routeLimits[pattern]
+		if !found {
+			limit = cfg.defaultLimit
+		}
+
+		client := "ip " + ratelimit.ClientKey(ratelimit.ClientIP(r, app.config.trustedProxies))
+		if key := r.Header.Get("X-API-Key"); key != "" && cfg.apiKeys[key] {
+			client = "key " + key
+			limit.Requests *= apiKeyLimitFactor
+		}
+		if limit.Unlimited() {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		result := limiter.Allow(pattern+" "+client, limit)
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Requests))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		w.Header().Set("RateLimit-Reset", ceilSeconds(result.Reset))
+		w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Requests, int(limit.Period.Seconds())))
+
+		if !result.Allowed {
+			app.rateLimitExceededResponse(w, r, ceilSeconds(result.RetryAfter))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// ceilSeconds formats a duration as whole seconds, rounding up.
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
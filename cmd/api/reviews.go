@@ -1,131 +1,156 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
-	"os"
-	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/brianrahadi/sfucourses-api/internal/reviews"
+	"github.com/brianrahadi/sfucourses-api/internal/store"
 )
 
-const instructorReviewDataDir = "internal/store/json/instructor_reviews"
-const courseReviewDataDir = "internal/store/json/course_reviews"
-const allInstructorReviewsFile = "internal/store/json/all_instructor_reviews.json"
-const allCourseReviewsFile = "internal/store/json/all_course_reviews.json"
-
-// normalizeName converts a name to match the file naming convention
-func normalizeName(name string) string {
-	// Convert to lowercase and replace spaces with underscores
-	return strings.ReplaceAll(strings.ToLower(name), " ", "_")
-}
-
-// normalizeCourseCode converts a course code to match the file naming convention
-func normalizeCourseCode(courseCode string) string {
-	// Convert to uppercase and remove spaces
-	return strings.ReplaceAll(strings.ToUpper(courseCode), " ", "")
-}
+const (
+	maxReviewLimit = 100
+	maxTrendWindow = 10
+)
 
-// findInstructorFile searches for an instructor file across all departments
-func findInstructorFile(instructorName string) (string, error) {
-	// Try different variations of the name
-	variations := []string{
-		normalizeName(instructorName),
-		strings.ReplaceAll(instructorName, " ", "_"),
-		strings.ReplaceAll(instructorName, " ", ""),
+// parseReviewQuery reads the review filter, sort and pagination parameters.
+func parseReviewQuery(r *http.Request) (reviews.Query, error) {
+	params := r.URL.Query()
+	q := reviews.Query{
+		CourseCode:     params.Get("course"),
+		Grades:         splitList(params.Get("grade")),
+		Tags:           splitList(params.Get("tag")),
+		WouldTakeAgain: strings.ToLower(params.Get("wouldTakeAgain")),
+		OnlineClass:    strings.ToLower(params.Get("online")),
+		Sort:           strings.ToLower(params.Get("sort")),
 	}
 
-	// Try to find the file by checking each department
-	departments, err := os.ReadDir(instructorReviewDataDir)
-	if err != nil {
-		return "", err
+	for name, value := range map[string]string{"wouldTakeAgain": q.WouldTakeAgain, "online": q.OnlineClass} {
+		if value != "" && value != "yes" && value != "no" {
+			return q, fmt.Errorf("%s must be yes or no", name)
+		}
 	}
 
-	for _, dept := range departments {
-		if dept.IsDir() {
-			deptPath := filepath.Join(instructorReviewDataDir, dept.Name())
-			files, err := os.ReadDir(deptPath)
-			if err != nil {
-				continue
-			}
-
-			for _, file := range files {
-				if !file.IsDir() && strings.HasSuffix(file.Name(), ".json") {
-					filename := strings.TrimSuffix(file.Name(), ".json")
-
-					for _, variation := range variations {
-						if strings.EqualFold(filename, variation) {
-							return filepath.Join(deptPath, file.Name()), nil
-						}
-					}
-				}
-			}
+	var err error
+	if from := params.Get("from"); from != "" {
+		if q.From, err = time.Parse(reviews.DateLayout, from); err != nil {
+			return q, errors.New("from must be a date in YYYY-MM-DD format")
+		}
+	}
+	if to := params.Get("to"); to != "" {
+		if q.To, err = time.Parse(reviews.DateLayout, to); err != nil {
+			return q, errors.New("to must be a date in YYYY-MM-DD format")
 		}
 	}
 
-	return "", errors.New("instructor not found")
-}
+	switch q.Sort {
+	case "", reviews.SortDate, reviews.SortHelpful, reviews.SortRating, reviews.SortDifficulty:
+	default:
+		return q, errors.New("sort must be one of date, helpful, rating, difficulty")
+	}
 
-// findCourseFile searches for a course file across all departments
-func findCourseFile(courseCode string) (string, error) {
-	variations := []string{
-		normalizeCourseCode(courseCode),
-		strings.ToUpper(courseCode),
-		strings.ToLower(courseCode),
+	switch strings.ToLower(params.Get("order")) {
+	case "", "desc":
+	case "asc":
+		q.Ascending = true
+	default:
+		return q, errors.New("order must be asc or desc")
 	}
 
-	entries, err := os.ReadDir(courseReviewDataDir)
-	if err != nil {
-		return "", err
+	if limit := params.Get("limit"); limit != "" {
+		if q.Limit, err = strconv.Atoi(limit); err != nil || q.Limit < 1 || q.Limit > maxReviewLimit {
+			return q, fmt.Errorf("limit must be between 1 and %d", maxReviewLimit)
+		}
+	}
+	if offset := params.Get("offset"); offset != "" {
+		if q.Offset, err = strconv.Atoi(offset); err != nil || q.Offset < 0 {
+			return q, errors.New("offset must be a non-negative integer")
+		}
 	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
-			filename := strings.TrimSuffix(entry.Name(), ".json")
+	return q, nil
+}
 
-			for _, variation := range variations {
-				if strings.EqualFold(filename, variation) {
-					return filepath.Join(courseReviewDataDir, entry.Name()), nil
-				}
-			}
+// splitList splits a comma-separated query parameter, dropping empty entries.
+func splitList(value string) []string {
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
 		}
 	}
-
-	return "", errors.New("course not found")
+	return result
 }
 
-// findAllCourseFiles searches for all course JSON files matching the course code across all department directories.
-// It returns all matching files to allow for data aggregation.
-func findAllCourseFiles(courseCode string) ([]string, error) {
-	variations := []string{
-		normalizeCourseCode(courseCode),
-		strings.ToUpper(courseCode),
-		strings.ToLower(courseCode),
+// applyInstructorReviewQuery filters, sorts and paginates the reviews of an
+// instructor, setting the page when the query isn't empty.
+func applyInstructorReviewQuery(reviewData model.InstructorReviewData, q reviews.Query) model.InstructorReviewData {
+	if q.IsZero() {
+		return reviewData
 	}
 
-	var matchingFiles []string
+	var total int
+	reviewData.Reviews, total = reviews.Apply(reviewData.Reviews, q)
+	reviewData.Page = &model.ReviewPage{Total: total, Limit: q.Limit, Offset: q.Offset}
+	return reviewData
+}
 
-	entries, err := os.ReadDir(courseReviewDataDir)
-	if err != nil {
-		return nil, err
+// applyCourseReviewQuery filters, sorts and paginates the reviews of every
+// instructor of a course. Instructors without matching reviews are dropped when
+// filtering.
+func applyCourseReviewQuery(courseData model.CourseReviewData, q reviews.Query) model.CourseReviewData {
+	if q.IsZero() {
+		return courseData
 	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
-			filename := strings.TrimSuffix(entry.Name(), ".json")
+	// Copy the instructors so the cached course data isn't modified
+	instructors := make([]model.InstructorSummary, 0, len(courseData.Instructors))
+	for _, instructor := range courseData.Instructors {
+		page, total := reviews.Apply(instructor.Reviews, q)
+		if total == 0 && q.HasFilters() {
+			continue
+		}
+		instructor.Reviews = page
+		instructor.Page = &model.ReviewPage{Total: total, Limit: q.Limit, Offset: q.Offset}
+		instructors = append(instructors, instructor)
+	}
+	courseData.Instructors = instructors
+	return courseData
+}
 
-			for _, variation := range variations {
-				if strings.EqualFold(filename, variation) {
-					matchingFiles = append(matchingFiles, filepath.Join(courseReviewDataDir, entry.Name()))
-					break
-				}
+// loadInstructorReviewData returns the reviews of an instructor by ID or name.
+// Instructors in the alias table use their most reviewed file, others fall
+// back to matching the review file name.
+// Returns store.ErrNotFound if the instructor has no reviews.
+func (app *application) loadInstructorReviewData(ctx context.Context, nameOrID string) (model.InstructorReviewData, error) {
+	instructorIdentity, err := app.store.Identities.Lookup(ctx, nameOrID)
+	switch {
+	case err == nil:
+		if len(instructorIdentity.ReviewFiles) == 0 {
+			return model.InstructorReviewData{}, store.ErrNotFound
+		}
+		files := make([]model.InstructorReviewData, 0, len(instructorIdentity.ReviewFiles))
+		for _, file := range instructorIdentity.ReviewFiles {
+			reviewData, err := app.store.Reviews.GetInstructor(ctx, file)
+			if err != nil {
+				return model.InstructorReviewData{}, err
 			}
+			files = append(files, reviewData)
 		}
+		reviewData := reviews.AggregateInstructor(files)
+		reviewData.InstructorID = instructorIdentity.ID
+		return reviewData, nil
+	case errors.Is(err, store.ErrNotFound):
+		return app.store.Reviews.FindInstructor(ctx, nameOrID)
+	default:
+		return model.InstructorReviewData{}, err
 	}
-
-	return matchingFiles, nil
 }
 
 // @Summary		Get all instructor reviews overview
@@ -137,19 +162,12 @@ func findAllCourseFiles(courseCode string) ([]string, error) {
 // @Failure		500	{object}	ErrorResponse			"Internal server error"
 // @Router			/v1/rest/reviews/instructors [get]
 func (app *application) getAllInstructorReviews(w http.ResponseWriter, r *http.Request) {
-	// Read and parse the all_instructor_reviews.json file
-	data, err := os.ReadFile(allInstructorReviewsFile)
+	summaries, err := app.store.Reviews.GetAllInstructors(r.Context())
 	if err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
 
-	var summaries []model.ProfessorSummary
-	if err := json.Unmarshal(data, &summaries); err != nil {
-		app.internalServerError(w, r, err)
-		return
-	}
-
 	if err := writeJSON(w, http.StatusOK, summaries); err != nil {
 		app.internalServerError(w, r, err)
 		return
@@ -157,12 +175,24 @@ func (app *application) getAllInstructorReviews(w http.ResponseWriter, r *http.R
 }
 
 // @Summary		Get instructor reviews
-// @Description	Retrieves detailed review data for a specific instructor by name
+// @Description	Retrieves detailed review data for a specific instructor by name. Reviews can be filtered, sorted and paginated; the page field then holds the number of matching reviews.
 // @Tags			Reviews
 // @Accept			json
 // @Produce		json
-// @Param			instructor_name	path		string						false	"Instructor name (e.g., Angela_Lin, Angela Lin)"
+// @Param			instructor_name	path		string						false	"Instructor ID or name (e.g., alin, Angela_Lin, Angela Lin)"
+// @Param			course			query		string						false	"Only reviews of this course code (e.g., CMPT225)"
+// @Param			from			query		string						false	"Only reviews on or after this date (YYYY-MM-DD)"
+// @Param			to				query		string						false	"Only reviews on or before this date (YYYY-MM-DD)"
+// @Param			grade			query		string						false	"Only reviews with one of these comma-separated grades (e.g., A+,A,A-)"
+// @Param			tag				query		string						false	"Only reviews with all of these comma-separated tags (e.g., Caring,Tough grader)"
+// @Param			wouldTakeAgain	query		string						false	"Only reviews that would (yes) or would not (no) take the class again"
+// @Param			online			query		string						false	"Only reviews of online (yes) or in-person (no) classes"
+// @Param			sort			query		string						false	"Sort by date, helpful, rating or difficulty (default: file order)"
+// @Param			order			query		string						false	"Sort order, asc or desc (default desc)"
+// @Param			limit			query		int							false	"Maximum number of reviews (1-100, default unlimited)"
+// @Param			offset			query		int							false	"Number of matching reviews to skip (default 0)"
 // @Success		200				{object}	model.InstructorReviewData	"Instructor review data"
+// @Failure		400				{object}	ErrorResponse				"Invalid filter, sort or pagination parameter"
 // @Failure		404				{object}	ErrorResponse				"Instructor not found"
 // @Failure		500				{object}	ErrorResponse				"Internal server error"
 // @Router			/v1/rest/reviews/instructors/{instructor_name} [get]
@@ -183,41 +213,50 @@ func (app *application) getInstructorReviews(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// Find the instructor file
-	filePath, err := findInstructorFile(instructorName)
+	q, err := parseReviewQuery(r)
 	if err != nil {
-		app.notFoundResponse(w, r, errors.New("instructor not found"))
+		app.badRequestResponse(w, r, err)
 		return
 	}
 
-	// Read and parse the JSON file
-	data, err := os.ReadFile(filePath)
+	reviewData, err := app.loadInstructorReviewData(r.Context(), instructorName)
 	if err != nil {
-		app.internalServerError(w, r, err)
-		return
-	}
-
-	var reviewData model.InstructorReviewData
-	if err := json.Unmarshal(data, &reviewData); err != nil {
-		app.internalServerError(w, r, err)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, errors.New("instructor not found"))
+		default:
+			app.internalServerError(w, r, err)
+		}
 		return
 	}
 
-	if err := writeJSON(w, http.StatusOK, reviewData); err != nil {
+	if err := writeJSON(w, http.StatusOK, applyInstructorReviewQuery(reviewData, q)); err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
 }
 
 // @Summary		Get course reviews
-// @Description	Retrieves precomputed review data for a specific course code
+// @Description	Retrieves precomputed review data for a specific course code. The reviews of each instructor can be filtered, sorted and paginated; instructors without matching reviews are left out when filtering.
 // @Tags			Reviews
 // @Accept			json
 // @Produce		json
-// @Param			course_code	path		string					false	"Course code (e.g., CMPT353, BUS251)"
-// @Success		200			{object}	model.CourseReviewData	"Course review data"
-// @Failure		404			{object}	ErrorResponse			"Course not found"
-// @Failure		500			{object}	ErrorResponse			"Internal server error"
+// @Param			course_code		path		string					false	"Course code (e.g., CMPT353, BUS251)"
+// @Param			course			query		string					false	"Only reviews of this course, matched against the normalized catalog course code (e.g., CMPT225, cmpt 225). Reviews whose code could not be resolved are quarantined and never match"
+// @Param			from			query		string					false	"Only reviews on or after this date (YYYY-MM-DD)"
+// @Param			to				query		string					false	"Only reviews on or before this date (YYYY-MM-DD)"
+// @Param			grade			query		string					false	"Only reviews with one of these comma-separated grades (e.g., A+,A,A-)"
+// @Param			tag				query		string					false	"Only reviews with all of these comma-separated tags (e.g., Caring,Tough grader)"
+// @Param			wouldTakeAgain	query		string					false	"Only reviews that would (yes) or would not (no) take the class again"
+// @Param			online			query		string					false	"Only reviews of online (yes) or in-person (no) classes"
+// @Param			sort			query		string					false	"Sort by date, helpful, rating or difficulty (default: file order)"
+// @Param			order			query		string					false	"Sort order, asc or desc (default desc)"
+// @Param			limit			query		int						false	"Maximum number of reviews (1-100, default unlimited)"
+// @Param			offset			query		int						false	"Number of matching reviews to skip (default 0)"
+// @Success		200				{object}	model.CourseReviewData	"Course review data"
+// @Failure		400				{object}	ErrorResponse			"Invalid filter, sort or pagination parameter"
+// @Failure		404				{object}	ErrorResponse			"Course not found"
+// @Failure		500				{object}	ErrorResponse			"Internal server error"
 // @Router			/v1/rest/reviews/courses/{course_code} [get]
 func (app *application) getCourseReviews(w http.ResponseWriter, r *http.Request) {
 	courseCode := r.PathValue("course_code")
@@ -227,44 +266,24 @@ func (app *application) getCourseReviews(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Find all matching course files
-	filePaths, err := findAllCourseFiles(courseCode)
-	if err != nil || len(filePaths) == 0 {
-		app.notFoundResponse(w, r, errors.New("course not found"))
+	q, err := parseReviewQuery(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
 		return
 	}
 
-	// Aggregate data from all matching files
-	var aggregatedData model.CourseReviewData
-	var allInstructors []model.InstructorSummary
-	totalReviews := 0
-
-	for _, filePath := range filePaths {
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			continue // Skip files that can't be read
-		}
-
-		var courseData model.CourseReviewData
-		if err := json.Unmarshal(data, &courseData); err != nil {
-			continue // Skip files that can't be parsed
-		}
-
-		// Set course code from first file
-		if aggregatedData.CourseCode == "" {
-			aggregatedData.CourseCode = courseData.CourseCode
+	courseData, err := app.store.Reviews.GetCourse(r.Context(), courseCode)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, errors.New("course not found"))
+		default:
+			app.internalServerError(w, r, err)
 		}
-
-		// Aggregate instructors and reviews
-		allInstructors = append(allInstructors, courseData.Instructors...)
-		totalReviews += courseData.TotalReviews
+		return
 	}
 
-	// Set aggregated data
-	aggregatedData.Instructors = allInstructors
-	aggregatedData.TotalReviews = totalReviews
-
-	if err := writeJSON(w, http.StatusOK, aggregatedData); err != nil {
+	if err := writeJSON(w, http.StatusOK, applyCourseReviewQuery(courseData, q)); err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
@@ -279,20 +298,240 @@ func (app *application) getCourseReviews(w http.ResponseWriter, r *http.Request)
 // @Failure		500	{object}	ErrorResponse		"Internal server error"
 // @Router			/v1/rest/reviews/courses [get]
 func (app *application) getAllCourseReviews(w http.ResponseWriter, r *http.Request) {
-	// Read and parse the all_course_reviews.json file
-	data, err := os.ReadFile(allCourseReviewsFile)
+	summaries, err := app.store.Reviews.GetAllCourses(r.Context())
 	if err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
 
-	var summaries []model.CourseSummary
-	if err := json.Unmarshal(data, &summaries); err != nil {
+	if err := writeJSON(w, http.StatusOK, summaries); err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
+}
 
-	if err := writeJSON(w, http.StatusOK, summaries); err != nil {
+// @Summary		Get quarantined review course codes
+// @Description	Returns the course codes entered by reviewers that couldn't be resolved to a course of the outline catalog, most reviewed first. Reviews with these codes are left out of course reviews but stay on instructor reviews.
+// @Tags			Reviews
+// @Accept			json
+// @Produce		json
+// @Success		200	{array}		model.QuarantinedCourseCode	"Unresolved course codes"
+// @Failure		500	{object}	ErrorResponse				"Internal server error"
+// @Router			/v1/rest/reviews/quarantine [get]
+func (app *application) getQuarantinedCourseCodes(w http.ResponseWriter, r *http.Request) {
+	quarantine, err := app.store.Reviews.GetQuarantine(r.Context())
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, quarantine); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+}
+
+// parseReviewStatsQuery reads the review filters and the number of top tags.
+// Sorting and pagination don't affect stats and are rejected.
+func parseReviewStatsQuery(r *http.Request) (reviews.Query, int, error) {
+	for _, param := range []string{"sort", "order", "limit", "offset"} {
+		if r.URL.Query().Has(param) {
+			return reviews.Query{}, 0, fmt.Errorf("%s is not supported by review stats", param)
+		}
+	}
+
+	q, err := parseReviewQuery(r)
+	if err != nil {
+		return q, 0, err
+	}
+
+	topTags := reviews.DefaultTopTags
+	if value := r.URL.Query().Get("topTags"); value != "" {
+		if topTags, err = strconv.Atoi(value); err != nil || topTags < 1 || topTags > maxReviewLimit {
+			return q, 0, fmt.Errorf("topTags must be between 1 and %d", maxReviewLimit)
+		}
+	}
+
+	return q, topTags, nil
+}
+
+// @Summary		Get instructor review stats
+// @Description	Returns rating and difficulty histograms, the self-reported grade distribution, top tags, and the textbook, attendance-mandatory and would-take-again rates of an instructor's reviews
+// @Tags			Reviews
+// @Accept			json
+// @Produce		json
+// @Param			instructor_name	path		string				true	"Instructor ID or name (e.g., alin, Angela_Lin, Angela Lin)"
+// @Param			course			query		string				false	"Only reviews of this course, matched against the normalized catalog course code (e.g., CMPT225, cmpt 225)"
+// @Param			from			query		string				false	"Only reviews on or after this date (YYYY-MM-DD)"
+// @Param			to				query		string				false	"Only reviews on or before this date (YYYY-MM-DD)"
+// @Param			grade			query		string				false	"Only reviews with one of these comma-separated grades (e.g., A+,A,A-)"
+// @Param			tag				query		string				false	"Only reviews with all of these comma-separated tags (e.g., Caring,Tough grader)"
+// @Param			wouldTakeAgain	query		string				false	"Only reviews that would (yes) or would not (no) take the class again"
+// @Param			online			query		string				false	"Only reviews of online (yes) or in-person (no) classes"
+// @Param			topTags			query		int					false	"Number of top tags (1-100, default 10)"
+// @Success		200				{object}	model.ReviewStats	"Review stats"
+// @Failure		400				{object}	ErrorResponse		"Invalid parameter"
+// @Failure		404				{object}	ErrorResponse		"Instructor not found"
+// @Failure		500				{object}	ErrorResponse		"Internal server error"
+// @Router			/v1/rest/reviews/instructors/{instructor_name}/stats [get]
+func (app *application) getInstructorReviewStats(w http.ResponseWriter, r *http.Request) {
+	instructorName := strings.TrimSpace(r.PathValue("instructor_name"))
+	if instructorName == "" {
+		app.badRequestResponse(w, r, errors.New("instructor name is required"))
+		return
+	}
+
+	q, topTags, err := parseReviewStatsQuery(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	reviewData, err := app.loadInstructorReviewData(r.Context(), instructorName)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, errors.New("instructor not found"))
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	matched, _ := reviews.Apply(reviewData.Reviews, q)
+	stats := reviews.BuildStats(matched, topTags)
+	stats.InstructorID = reviewData.InstructorID
+	stats.ProfessorName = reviewData.ProfessorName
+
+	if err := writeJSON(w, http.StatusOK, stats); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+}
+
+// @Summary		Get course review stats
+// @Description	Returns rating and difficulty histograms, the self-reported grade distribution, top tags, and the textbook, attendance-mandatory and would-take-again rates of a course's reviews across all instructors
+// @Tags			Reviews
+// @Accept			json
+// @Produce		json
+// @Param			course_code		path		string				true	"Course code (e.g., CMPT353, BUS251)"
+// @Param			course			query		string				false	"Only reviews of this course, matched against the normalized catalog course code (e.g., CMPT225, cmpt 225)"
+// @Param			from			query		string				false	"Only reviews on or after this date (YYYY-MM-DD)"
+// @Param			to				query		string				false	"Only reviews on or before this date (YYYY-MM-DD)"
+// @Param			grade			query		string				false	"Only reviews with one of these comma-separated grades (e.g., A+,A,A-)"
+// @Param			tag				query		string				false	"Only reviews with all of these comma-separated tags (e.g., Caring,Tough grader)"
+// @Param			wouldTakeAgain	query		string				false	"Only reviews that would (yes) or would not (no) take the class again"
+// @Param			online			query		string				false	"Only reviews of online (yes) or in-person (no) classes"
+// @Param			topTags			query		int					false	"Number of top tags (1-100, default 10)"
+// @Success		200				{object}	model.ReviewStats	"Review stats"
+// @Failure		400				{object}	ErrorResponse		"Invalid parameter"
+// @Failure		404				{object}	ErrorResponse		"Course not found"
+// @Failure		500				{object}	ErrorResponse		"Internal server error"
+// @Router			/v1/rest/reviews/courses/{course_code}/stats [get]
+func (app *application) getCourseReviewStats(w http.ResponseWriter, r *http.Request) {
+	courseCode := r.PathValue("course_code")
+	if courseCode == "" {
+		app.badRequestResponse(w, r, errors.New("course code is required"))
+		return
+	}
+
+	q, topTags, err := parseReviewStatsQuery(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	courseData, err := app.store.Reviews.GetCourse(r.Context(), courseCode)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, errors.New("course not found"))
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	var courseReviews []model.Review
+	for _, instructor := range courseData.Instructors {
+		matched, _ := reviews.Apply(instructor.Reviews, q)
+		courseReviews = append(courseReviews, matched...)
+	}
+
+	stats := reviews.BuildStats(courseReviews, topTags)
+	stats.CourseCode = courseData.CourseCode
+
+	if err := writeJSON(w, http.StatusOK, stats); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+}
+
+// @Summary		Get instructor rating trend
+// @Description	Returns the average rating and difficulty of an instructor's reviews per term or year, with rolling averages over the last few periods, to show whether an instructor got better or worse recently
+// @Tags			Reviews
+// @Accept			json
+// @Produce		json
+// @Param			instructor_name	path		string				true	"Instructor ID or name (e.g., alin, Angela_Lin, Angela Lin)"
+// @Param			by				query		string				false	"Period length (term or year, default term)"
+// @Param			course			query		string				false	"Only reviews of this course code (e.g., CMPT225)"
+// @Param			window			query		int					false	"Number of periods in each rolling average (1-10, default 3)"
+// @Success		200				{object}	model.ReviewTrend	"Rating trend"
+// @Failure		400				{object}	ErrorResponse		"Invalid parameter"
+// @Failure		404				{object}	ErrorResponse		"Instructor not found"
+// @Failure		500				{object}	ErrorResponse		"Internal server error"
+// @Router			/v1/rest/reviews/instructors/{instructor_name}/trend [get]
+func (app *application) getInstructorReviewTrend(w http.ResponseWriter, r *http.Request) {
+	instructorName := strings.TrimSpace(r.PathValue("instructor_name"))
+	if instructorName == "" {
+		app.badRequestResponse(w, r, errors.New("instructor name is required"))
+		return
+	}
+
+	query := r.URL.Query()
+	period := strings.ToLower(query.Get("by"))
+	switch period {
+	case "":
+		period = reviews.PeriodTerm
+	case reviews.PeriodTerm, reviews.PeriodYear:
+	default:
+		app.badRequestResponse(w, r, errors.New("by must be term or year"))
+		return
+	}
+
+	window := reviews.DefaultTrendWindow
+	if value := query.Get("window"); value != "" {
+		var err error
+		if window, err = strconv.Atoi(value); err != nil || window < 1 || window > maxTrendWindow {
+			app.badRequestResponse(w, r, fmt.Errorf("window must be between 1 and %d", maxTrendWindow))
+			return
+		}
+	}
+
+	reviewData, err := app.loadInstructorReviewData(r.Context(), instructorName)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, errors.New("instructor not found"))
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	courseCode := reviews.NormalizeCourseCode(query.Get("course"))
+	matched, _ := reviews.Apply(reviewData.Reviews, reviews.Query{CourseCode: courseCode})
+
+	trend := model.ReviewTrend{
+		InstructorID:  reviewData.InstructorID,
+		ProfessorName: reviewData.ProfessorName,
+		CourseCode:    courseCode,
+		Period:        period,
+		Window:        window,
+		Points:        reviews.BuildTrend(matched, period, window),
+	}
+
+	if err := writeJSON(w, http.StatusOK, trend); err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
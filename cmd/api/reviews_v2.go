@@ -0,0 +1,146 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/brianrahadi/sfucourses-api/internal/reviews"
+	"github.com/brianrahadi/sfucourses-api/internal/store"
+)
+
+// @Summary		Get all instructor reviews overview (v2)
+// @Description	Returns summary review data for all instructors with numeric ratings and percentages. Values missing from RateMyProfessors are null.
+// @Tags			Reviews
+// @Accept			json
+// @Produce		json
+// @Success		200	{array}		model.ProfessorSummaryV2	"List of instructor summaries"
+// @Failure		500	{object}	ErrorResponse				"Internal server error"
+// @Router			/v2/rest/reviews/instructors [get]
+func (app *application) getAllInstructorReviewsV2(w http.ResponseWriter, r *http.Request) {
+	summaries, err := app.store.Reviews.GetAllInstructors(r.Context())
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, reviews.ProfessorSummariesV2(summaries)); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+}
+
+// @Summary		Get instructor reviews (v2)
+// @Description	Retrieves detailed review data for a specific instructor with numeric ratings, ISO dates and boolean review metadata. Values missing from RateMyProfessors are null. Accepts the same filter, sort and pagination parameters as the v1 endpoint.
+// @Tags			Reviews
+// @Accept			json
+// @Produce		json
+// @Param			instructor_name	path		string							true	"Instructor ID or name (e.g., alin, Angela_Lin, Angela Lin)"
+// @Param			course			query		string							false	"Only reviews of this course code (e.g., CMPT225)"
+// @Param			from			query		string							false	"Only reviews on or after this date (YYYY-MM-DD)"
+// @Param			to				query		string							false	"Only reviews on or before this date (YYYY-MM-DD)"
+// @Param			grade			query		string							false	"Only reviews with one of these comma-separated grades (e.g., A+,A,A-)"
+// @Param			tag				query		string							false	"Only reviews with all of these comma-separated tags (e.g., Caring,Tough grader)"
+// @Param			wouldTakeAgain	query		string							false	"Only reviews that would (yes) or would not (no) take the class again"
+// @Param			online			query		string							false	"Only reviews of online (yes) or in-person (no) classes"
+// @Param			sort			query		string							false	"Sort by date, helpful, rating or difficulty (default: file order)"
+// @Param			order			query		string							false	"Sort order, asc or desc (default desc)"
+// @Param			limit			query		int								false	"Maximum number of reviews (1-100, default unlimited)"
+// @Param			offset			query		int								false	"Number of matching reviews to skip (default 0)"
+// @Success		200				{object}	model.InstructorReviewDataV2	"Instructor review data"
+// @Failure		400				{object}	ErrorResponse					"Invalid filter, sort or pagination parameter"
+// @Failure		404				{object}	ErrorResponse					"Instructor not found"
+// @Failure		500				{object}	ErrorResponse					"Internal server error"
+// @Router			/v2/rest/reviews/instructors/{instructor_name} [get]
+func (app *application) getInstructorReviewsV2(w http.ResponseWriter, r *http.Request) {
+	instructorName := strings.TrimSpace(r.PathValue("instructor_name"))
+	if instructorName == "" {
+		app.badRequestResponse(w, r, errors.New("instructor name is required"))
+		return
+	}
+
+	q, err := parseReviewQuery(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	reviewData, err := app.loadInstructorReviewData(r.Context(), instructorName)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, errors.New("instructor not found"))
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, reviews.InstructorV2(applyInstructorReviewQuery(reviewData, q))); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+}
+
+// @Summary		Get course reviews (v2)
+// @Description	Retrieves review data for a specific course code with numeric ratings, ISO dates and boolean review metadata. Values missing from RateMyProfessors are null. Accepts the same filter, sort and pagination parameters as the v1 endpoint.
+// @Tags			Reviews
+// @Accept			json
+// @Produce		json
+// @Param			course_code		path		string						true	"Course code (e.g., CMPT353, BUS251)"
+// @Param			from			query		string						false	"Only reviews on or after this date (YYYY-MM-DD)"
+// @Param			to				query		string						false	"Only reviews on or before this date (YYYY-MM-DD)"
+// @Param			grade			query		string						false	"Only reviews with one of these comma-separated grades (e.g., A+,A,A-)"
+// @Param			tag				query		string						false	"Only reviews with all of these comma-separated tags (e.g., Caring,Tough grader)"
+// @Param			wouldTakeAgain	query		string						false	"Only reviews that would (yes) or would not (no) take the class again"
+// @Param			online			query		string						false	"Only reviews of online (yes) or in-person (no) classes"
+// @Param			sort			query		string						false	"Sort by date, helpful, rating or difficulty (default: file order)"
+// @Param			order			query		string						false	"Sort order, asc or desc (default desc)"
+// @Param			limit			query		int							false	"Maximum number of reviews (1-100, default unlimited)"
+// @Param			offset			query		int							false	"Number of matching reviews to skip (default 0)"
+// @Success		200				{object}	model.CourseReviewDataV2	"Course review data"
+// @Failure		400				{object}	ErrorResponse				"Invalid filter, sort or pagination parameter"
+// @Failure		404				{object}	ErrorResponse				"Course not found"
+// @Failure		500				{object}	ErrorResponse				"Internal server error"
+// @Router			/v2/rest/reviews/courses/{course_code} [get]
+func (app *application) getCourseReviewsV2(w http.ResponseWriter, r *http.Request) {
+	courseCode := r.PathValue("course_code")
+	if courseCode == "" {
+		app.badRequestResponse(w, r, errors.New("course code is required"))
+		return
+	}
+
+	q, err := parseReviewQuery(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	courseData, err := app.store.Reviews.GetCourse(r.Context(), courseCode)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			app.notFoundResponse(w, r, errors.New("course not found"))
+		default:
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, reviews.CourseV2(applyCourseReviewQuery(courseData, q))); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+}
+
+// @Summary		Get all course reviews overview (v2)
+// @Description	Returns summary review data for all courses. Course summaries are already numeric, so this matches the v1 response.
+// @Tags			Reviews
+// @Accept			json
+// @Produce		json
+// @Success		200	{array}		model.CourseSummary	"List of course summaries"
+// @Failure		500	{object}	ErrorResponse		"Internal server error"
+// @Router			/v2/rest/reviews/courses [get]
+func (app *application) getAllCourseReviewsV2(w http.ResponseWriter, r *http.Request) {
+	app.getAllCourseReviews(w, r)
+}
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/brianrahadi/sfucourses-api/internal/model"
 	"github.com/brianrahadi/sfucourses-api/internal/store"
 )
 
@@ -18,17 +22,21 @@ func splitYearTerm(yearTerm string) (year, term string, err error) {
 }
 
 // @Summary		Get sections
-// @Description	Retrieves course sections for a specific year and term, optionally filtered by department and/or course number
+// @Description	Retrieves course sections for a specific year and term, optionally filtered by department and/or course number. With includeRatings=true, every instructor with reviews gets their ratings inline. With limit or offset, returns one page of courses in a paginated envelope instead of the full list.
 // @Tags			Sections
 // @Accept			json
 // @Produce		json
-// @Param			term	query		string								true	"Year and term in format YYYY-Term (e.g., 2024-spring)"
-// @Param			dept	query		string								false	"Department code (e.g., cmpt, math)"
-// @Param			number	query		string								false	"Course number (e.g., 120, 225)"
-// @Success		200		{array}		[]model.CourseWithSectionDetails	"List of sections"
-// @Failure		400		{object}	ErrorResponse						"Invalid year-term format or query parameters"
-// @Failure		404		{object}	ErrorResponse						"No sections found for the specified criteria"
-// @Failure		500		{object}	ErrorResponse						"Internal server error"
+// @Param			term			query		string													true	"Year and term in format YYYY-Term (e.g., 2024-spring)"
+// @Param			dept			query		string													false	"Department code (e.g., cmpt, math)"
+// @Param			number			query		string													false	"Course number (e.g., 120, 225)"
+// @Param			includeRatings	query		bool													false	"Attach each instructor's review summary, including the averages of their reviews of the course (default false)"
+// @Param			limit			query		int														false	"Page size in courses (1-1000, default 100 when offset is set)"
+// @Param			offset			query		int														false	"Number of courses to skip (default 0)"
+// @Success		200				{object}	model.PaginatedResponse[model.CourseWithSectionDetails]	"Page of sections, if limit or offset is set"
+// @Success		200				{array}		[]model.CourseWithSectionDetails						"List of sections"
+// @Failure		400				{object}	ErrorResponse											"Invalid year-term format or query parameters"
+// @Failure		404				{object}	ErrorResponse											"No sections found for the specified criteria"
+// @Failure		500				{object}	ErrorResponse											"Internal server error"
 // @Router			/v1/rest/sections [get]
 func (app *application) getSections(w http.ResponseWriter, r *http.Request) {
 	term := r.URL.Query().Get("term")
@@ -47,6 +55,20 @@ func (app *application) getSections(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	includeRatings := false
+	if value := r.URL.Query().Get("includeRatings"); value != "" {
+		if includeRatings, err = strconv.ParseBool(value); err != nil {
+			app.badRequestResponse(w, r, errors.New("includeRatings must be true or false"))
+			return
+		}
+	}
+
+	page, paginated, err := parsePageQuery(r.URL.Query())
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
 	sections, err := app.store.Sections.Get(ctx, year, term, dept, number)
 	if err != nil {
 		switch {
@@ -58,8 +80,63 @@ func (app *application) getSections(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Ratings are only looked up for the requested page
+	if paginated {
+		response := paginate(r, sections, page)
+		if includeRatings {
+			if response.Data, err = app.withInstructorRatings(ctx, response.Data); err != nil {
+				app.internalServerError(w, r, err)
+				return
+			}
+		}
+
+		if err := writeJSON(w, http.StatusOK, response); err != nil {
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	if includeRatings {
+		if sections, err = app.withInstructorRatings(ctx, sections); err != nil {
+			app.internalServerError(w, r, err)
+			return
+		}
+	}
+
 	if err := writeJSON(w, http.StatusOK, sections); err != nil {
 		app.internalServerError(w, r, err)
 		return
 	}
 }
+
+// withInstructorRatings returns a copy of the courses with the review summary
+// of every section instructor that has reviews. The cached sections aren't
+// modified.
+func (app *application) withInstructorRatings(ctx context.Context, courses []model.CourseWithSectionDetails) ([]model.CourseWithSectionDetails, error) {
+	rated := make([]model.CourseWithSectionDetails, len(courses))
+	for i, course := range courses {
+		courseCode := course.Dept + course.Number
+		course.SectionDetails = slices.Clone(course.SectionDetails)
+
+		for j, section := range course.SectionDetails {
+			section.Instructors = slices.Clone(section.Instructors)
+			for k, instructor := range section.Instructors {
+				if instructor.ID == "" {
+					continue
+				}
+
+				ratings, err := app.store.Reviews.GetInstructorRatings(ctx, instructor.ID, courseCode)
+				switch {
+				case err == nil:
+					section.Instructors[k].Ratings = &ratings
+				case !errors.Is(err, store.ErrNotFound):
+					return nil, err
+				}
+			}
+			course.SectionDetails[j] = section
+		}
+
+		rated[i] = course
+	}
+	return rated, nil
+}
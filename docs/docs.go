@@ -15,35 +15,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/health": {
-            "get": {
-                "description": "Returns status and version information about the API",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Health"
-                ],
-                "summary": "Health check endpoint",
-                "responses": {
-                    "200": {
-                        "description": "Returns status and version information",
-                        "schema": {
-                            "$ref": "#/definitions/main.HealthResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/main.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/rest/instructors": {
+        "/graphql": {
             "get": {
-                "description": "Retrieves instructors with optional filtering by department, course number, or name",
+                "description": "Executes a GraphQL query over outlines, sections, instructors and reviews, linking courses to their offerings, sections, instructors and reviews, so clients fetch exactly the nested data they need in one round trip. The root fields are course, courses, search and instructor. Queries are sent as a JSON body with query, variables and operationName, or as query parameters on GET. Errors of individual fields are returned in the errors array of a 200 response. Queries nested deeper than 15 fields or estimated to resolve more than 50000 fields are rejected before execution, and lists return at most 100 items.",
                 "consumes": [
                     "application/json"
                 ],
@@ -51,57 +25,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Instructors"
+                    "GraphQL"
                 ],
-                "summary": "Get instructors",
+                "summary": "Query with GraphQL",
                 "parameters": [
+                    {
+                        "description": "GraphQL request, for POST",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/main.graphQLRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Department code (e.g., cmpt, math)",
-                        "name": "dept",
+                        "description": "GraphQL query, for GET",
+                        "name": "query",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Course number (e.g., 120, 225)",
-                        "name": "number",
+                        "description": "JSON object of variables, for GET",
+                        "name": "variables",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Instructor name (URL encoded)",
-                        "name": "name",
+                        "description": "Operation to execute, if the query has several, for GET",
+                        "name": "operationName",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of instructors",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.InstructorResponse"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "No instructors found",
+                        "description": "Query result, with data and errors",
                         "schema": {
-                            "$ref": "#/definitions/main.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Missing query or invalid request body",
                         "schema": {
                             "$ref": "#/definitions/main.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/rest/outlines": {
-            "get": {
-                "description": "Retrieves course outlines, optionally filtered by department and/or course number",
+            },
+            "post": {
+                "description": "Executes a GraphQL query over outlines, sections, instructors and reviews, linking courses to their offerings, sections, instructors and reviews, so clients fetch exactly the nested data they need in one round trip. The root fields are course, courses, search and instructor. Queries are sent as a JSON body with query, variables and operationName, or as query parameters on GET. Errors of individual fields are returned in the errors array of a 200 response. Queries nested deeper than 15 fields or estimated to resolve more than 50000 fields are rejected before execution, and lists return at most 100 items.",
                 "consumes": [
                     "application/json"
                 ],
@@ -109,59 +81,73 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Outlines"
+                    "GraphQL"
                 ],
-                "summary": "Get course outlines",
+                "summary": "Query with GraphQL",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Department code (e.g., cmpt, math)",
-                        "name": "dept",
-                        "in": "query"
+                        "description": "GraphQL request, for POST",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/main.graphQLRequest"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "Course number (e.g., 120, 225)",
-                        "name": "number",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Return short outline with only dept, number, title, and units",
-                        "name": "short",
+                        "description": "GraphQL query, for GET",
+                        "name": "query",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Return short outline with only dept, number, title, and units",
-                        "name": "SHORT",
+                        "type": "string",
+                        "description": "JSON object of variables, for GET",
+                        "name": "variables",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Include parsed prerequisite expression trees",
-                        "name": "prereqs",
+                        "type": "string",
+                        "description": "Operation to execute, if the query has several, for GET",
+                        "name": "operationName",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of course outlines",
+                        "description": "Query result, with data and errors",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.CourseOutline"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "404": {
-                        "description": "No outlines found for the specified criteria",
+                    "400": {
+                        "description": "Missing query or invalid request body",
                         "schema": {
                             "$ref": "#/definitions/main.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Returns status and version information about the API",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Health check endpoint",
+                "responses": {
+                    "200": {
+                        "description": "Returns status and version information",
+                        "schema": {
+                            "$ref": "#/definitions/main.HealthResponse"
+                        }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/main.ErrorResponse"
                         }
@@ -169,9 +155,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/rest/prerequisites": {
+        "/v1/rest/analytics/timetable": {
             "get": {
-                "description": "Returns prerequisite expression trees for all courses, optionally filtered by department and/or course number",
+                "description": "Aggregates the weekly scheduled class-hours of a term by campus, weekday and 30-minute slot, optionally filtered by campus, department and section type",
                 "consumes": [
                     "application/json"
                 ],
@@ -179,10 +165,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Prerequisites"
+                    "Analytics"
                 ],
-                "summary": "Get parsed course prerequisites",
+                "summary": "Get timetable heatmap",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Year and term in format YYYY-Term (e.g., 2024-spring)",
+                        "name": "term",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campus (e.g., Burnaby, Surrey)",
+                        "name": "campus",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Department code (e.g., cmpt, math)",
@@ -191,20 +190,26 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Course number (e.g., 120, 225)",
-                        "name": "number",
+                        "description": "Section type (e.g., LEC, TUT, LAB)",
+                        "name": "type",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Map of course codes to prerequisite expression trees",
+                        "description": "Timetable heatmap",
                         "schema": {
-                            "$ref": "#/definitions/model.PrereqMap"
+                            "$ref": "#/definitions/model.TimetableHeatmap"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid year-term format or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "No prerequisites found for the specified criteria",
+                        "description": "No sections found for the specified criteria",
                         "schema": {
                             "$ref": "#/definitions/main.ErrorResponse"
                         }
@@ -218,9 +223,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/rest/reviews/courses": {
+        "/v1/rest/graph": {
             "get": {
-                "description": "Returns summary review data for all courses",
+                "description": "Returns the graph of instructors and courses across all loaded terms. Instructors are connected to the courses they taught (\"taught\") and to the instructors they shared a section with (\"co-taught\"). Edges are weighted by number of terms.",
                 "consumes": [
                     "application/json"
                 ],
@@ -228,17 +233,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Graph"
+                ],
+                "summary": "Get teaching graph",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Department code to limit the graph to (e.g., cmpt, math)",
+                        "name": "dept",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all course reviews overview",
                 "responses": {
                     "200": {
-                        "description": "List of course summaries",
+                        "description": "Teaching graph",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.CourseSummary"
-                            }
+                            "$ref": "#/definitions/model.TeachingGraph"
                         }
                     },
                     "500": {
@@ -250,9 +260,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/rest/reviews/courses/{course_code}": {
+        "/v1/rest/graph/courses/{dept}/{number}": {
             "get": {
-                "description": "Retrieves precomputed review data for a specific course code",
+                "description": "Returns a course with every instructor who taught it, and the co-taught edges between those instructors for that course",
                 "consumes": [
                     "application/json"
                 ],
@@ -260,22 +270,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Graph"
                 ],
-                "summary": "Get course reviews",
+                "summary": "Get course neighbourhood",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Course code (e.g., CMPT353, BUS251)",
-                        "name": "course_code",
-                        "in": "path"
+                        "description": "Department code (e.g., cmpt, math)",
+                        "name": "dept",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Course number (e.g., 120, 225)",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Course review data",
+                        "description": "Course neighbourhood",
                         "schema": {
-                            "$ref": "#/definitions/model.CourseReviewData"
+                            "$ref": "#/definitions/model.TeachingGraph"
                         }
                     },
                     "404": {
@@ -293,9 +311,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/rest/reviews/instructors": {
+        "/v1/rest/graph/instructors/{name}": {
             "get": {
-                "description": "Returns summary review data for all instructors",
+                "description": "Returns an instructor with the courses they taught and the instructors they co-taught with. With depth=2, the other instructors who taught the same courses are included.",
                 "consumes": [
                     "application/json"
                 ],
@@ -303,17 +321,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Graph"
+                ],
+                "summary": "Get instructor neighbourhood",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instructor ID or name (e.g., alin, Angela_Lin, Angela Lin)",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1 for direct neighbours (default), 2 to include other instructors of the same courses",
+                        "name": "depth",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all instructor reviews overview",
                 "responses": {
                     "200": {
-                        "description": "List of instructor summaries",
+                        "description": "Instructor neighbourhood",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ProfessorSummary"
-                            }
+                            "$ref": "#/definitions/model.TeachingGraph"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid instructor name or depth",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Instructor not found",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
                         }
                     },
                     "500": {
@@ -325,9 +367,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/rest/reviews/instructors/{instructor_name}": {
+        "/v1/rest/graph/team-taught": {
             "get": {
-                "description": "Retrieves detailed review data for a specific instructor by name",
+                "description": "Returns the courses with at least one section taught by more than one instructor",
                 "consumes": [
                     "application/json"
                 ],
@@ -335,28 +377,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Graph"
                 ],
-                "summary": "Get instructor reviews",
+                "summary": "Get team-taught courses",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Instructor name (e.g., Angela_Lin, Angela Lin)",
-                        "name": "instructor_name",
-                        "in": "path"
+                        "description": "Department code (e.g., cmpt, math)",
+                        "name": "dept",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Instructor review data",
-                        "schema": {
-                            "$ref": "#/definitions/model.InstructorReviewData"
-                        }
-                    },
-                    "404": {
-                        "description": "Instructor not found",
+                        "description": "Team-taught courses",
                         "schema": {
-                            "$ref": "#/definitions/main.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TeamTaughtCourse"
+                            }
                         }
                     },
                     "500": {
@@ -368,9 +407,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/rest/sections": {
+        "/v1/rest/instructors": {
             "get": {
-                "description": "Retrieves course sections for a specific year and term, optionally filtered by department and/or course number",
+                "description": "Retrieves instructors with optional filtering by department, course number, or name. With limit or offset, returns one page of instructors in a paginated envelope instead of the full list.",
                 "consumes": [
                     "application/json"
                 ],
@@ -378,17 +417,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Sections"
+                    "Instructors"
                 ],
-                "summary": "Get sections",
+                "summary": "Get instructors",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Year and term in format YYYY-Term (e.g., 2024-spring)",
-                        "name": "term",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "Department code (e.g., cmpt, math)",
@@ -400,29 +432,44 @@ const docTemplate = `{
                         "description": "Course number (e.g., 120, 225)",
                         "name": "number",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Instructor name (URL encoded)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-1000, default 100 when offset is set)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of instructors to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of sections",
+                        "description": "List of instructors",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/model.CourseWithSectionDetails"
-                                }
+                                "$ref": "#/definitions/model.InstructorResponse"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid year-term format or query parameters",
+                        "description": "Invalid limit or offset",
                         "schema": {
                             "$ref": "#/definitions/main.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "No sections found for the specified criteria",
+                        "description": "No instructors found",
                         "schema": {
                             "$ref": "#/definitions/main.ErrorResponse"
                         }
@@ -435,207 +482,2167 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
+        },
+        "/v1/rest/instructors/search": {
+            "get": {
+                "description": "Searches instructors by name or alias, ranked by relevance. Matching ignores case, accents and word order, and tolerates small typos.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Instructors"
+                ],
+                "summary": "Search instructors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query (e.g., muller, cote isabelle)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of results (default 10, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching instructors, best match first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.InstructorSearchResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Missing query or invalid limit",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rest/instructors/{name}/profile": {
+            "get": {
+                "description": "Retrieves an instructor's teaching history, current-term sections with schedules, email, and review summary in a single response",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Instructors"
+                ],
+                "summary": "Get instructor profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instructor ID or name (e.g., alin, Angela_Lin, Angela Lin)",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Year and term used for current sections in format YYYY-Term (defaults to the current term)",
+                        "name": "term",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Instructor profile",
+                        "schema": {
+                            "$ref": "#/definitions/model.InstructorProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid instructor name or year-term format",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Instructor not found",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rest/instructors/{name}/stats": {
+            "get": {
+                "description": "Returns teaching statistics of an instructor: courses taught per term, distinct courses, departments, total sections, lecture vs lab split, and the first and last term taught",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Instructors"
+                ],
+                "summary": "Get instructor teaching stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instructor ID or name (e.g., alin, Angela_Lin, Angela Lin)",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Instructor teaching stats",
+                        "schema": {
+                            "$ref": "#/definitions/model.InstructorStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid instructor name",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Instructor not found",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rest/outlines": {
+            "get": {
+                "description": "Retrieves course outlines, optionally filtered by department and/or course number. With limit or offset, returns one page of outlines in a paginated envelope instead of the full list.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Outlines"
+                ],
+                "summary": "Get course outlines",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Department code (e.g., cmpt, math)",
+                        "name": "dept",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Course number (e.g., 120, 225)",
+                        "name": "number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return short outline with only dept, number, title, and units",
+                        "name": "short",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include parsed prerequisite expression trees",
+                        "name": "prereqs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-1000, default 100 when offset is set)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of outlines to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of course outlines",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CourseOutline"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit or offset",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No outlines found for the specified criteria",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rest/prerequisites": {
+            "get": {
+                "description": "Returns prerequisite expression trees for all courses, optionally filtered by department and/or course number",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prerequisites"
+                ],
+                "summary": "Get parsed course prerequisites",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Department code (e.g., cmpt, math)",
+                        "name": "dept",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Course number (e.g., 120, 225)",
+                        "name": "number",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Map of course codes to prerequisite expression trees",
+                        "schema": {
+                            "$ref": "#/definitions/model.PrereqMap"
+                        }
+                    },
+                    "404": {
+                        "description": "No prerequisites found for the specified criteria",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rest/reviews/courses": {
+            "get": {
+                "description": "Returns summary review data for all courses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get all course reviews overview",
+                "responses": {
+                    "200": {
+                        "description": "List of course summaries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CourseSummary"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rest/reviews/courses/{course_code}": {
+            "get": {
+                "description": "Retrieves precomputed review data for a specific course code. The reviews of each instructor can be filtered, sorted and paginated; instructors without matching reviews are left out when filtering.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get course reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course code (e.g., CMPT353, BUS251)",
+                        "name": "course_code",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews of this course, matched against the normalized catalog course code (e.g., CMPT225, cmpt 225). Reviews whose code could not be resolved are quarantined and never match",
+                        "name": "course",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews on or after this date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews on or before this date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews with one of these comma-separated grades (e.g., A+,A,A-)",
+                        "name": "grade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews with all of these comma-separated tags (e.g., Caring,Tough grader)",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews that would (yes) or would not (no) take the class again",
+                        "name": "wouldTakeAgain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews of online (yes) or in-person (no) classes",
+                        "name": "online",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by date, helpful, rating or difficulty (default: file order)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order, asc or desc (default desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of reviews (1-100, default unlimited)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of matching reviews to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Course review data",
+                        "schema": {
+                            "$ref": "#/definitions/model.CourseReviewData"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or pagination parameter",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Course not found",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rest/reviews/courses/{course_code}/stats": {
+            "get": {
+                "description": "Returns rating and difficulty histograms, the self-reported grade distribution, top tags, and the textbook, attendance-mandatory and would-take-again rates of a course's reviews across all instructors",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get course review stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course code (e.g., CMPT353, BUS251)",
+                        "name": "course_code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews of this course, matched against the normalized catalog course code (e.g., CMPT225, cmpt 225)",
+                        "name": "course",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews on or after this date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews on or before this date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews with one of these comma-separated grades (e.g., A+,A,A-)",
+                        "name": "grade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews with all of these comma-separated tags (e.g., Caring,Tough grader)",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews that would (yes) or would not (no) take the class again",
+                        "name": "wouldTakeAgain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews of online (yes) or in-person (no) classes",
+                        "name": "online",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of top tags (1-100, default 10)",
+                        "name": "topTags",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review stats",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReviewStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Course not found",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rest/reviews/instructors": {
+            "get": {
+                "description": "Returns summary review data for all instructors",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get all instructor reviews overview",
+                "responses": {
+                    "200": {
+                        "description": "List of instructor summaries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ProfessorSummary"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rest/reviews/instructors/{instructor_name}": {
+            "get": {
+                "description": "Retrieves detailed review data for a specific instructor by name. Reviews can be filtered, sorted and paginated; the page field then holds the number of matching reviews.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get instructor reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instructor ID or name (e.g., alin, Angela_Lin, Angela Lin)",
+                        "name": "instructor_name",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews of this course code (e.g., CMPT225)",
+                        "name": "course",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews on or after this date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews on or before this date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews with one of these comma-separated grades (e.g., A+,A,A-)",
+                        "name": "grade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews with all of these comma-separated tags (e.g., Caring,Tough grader)",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews that would (yes) or would not (no) take the class again",
+                        "name": "wouldTakeAgain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews of online (yes) or in-person (no) classes",
+                        "name": "online",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by date, helpful, rating or difficulty (default: file order)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order, asc or desc (default desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of reviews (1-100, default unlimited)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of matching reviews to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Instructor review data",
+                        "schema": {
+                            "$ref": "#/definitions/model.InstructorReviewData"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or pagination parameter",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Instructor not found",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rest/reviews/instructors/{instructor_name}/stats": {
+            "get": {
+                "description": "Returns rating and difficulty histograms, the self-reported grade distribution, top tags, and the textbook, attendance-mandatory and would-take-again rates of an instructor's reviews",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get instructor review stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instructor ID or name (e.g., alin, Angela_Lin, Angela Lin)",
+                        "name": "instructor_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews of this course, matched against the normalized catalog course code (e.g., CMPT225, cmpt 225)",
+                        "name": "course",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews on or after this date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews on or before this date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews with one of these comma-separated grades (e.g., A+,A,A-)",
+                        "name": "grade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews with all of these comma-separated tags (e.g., Caring,Tough grader)",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews that would (yes) or would not (no) take the class again",
+                        "name": "wouldTakeAgain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews of online (yes) or in-person (no) classes",
+                        "name": "online",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of top tags (1-100, default 10)",
+                        "name": "topTags",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review stats",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReviewStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Instructor not found",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rest/reviews/instructors/{instructor_name}/trend": {
+            "get": {
+                "description": "Returns the average rating and difficulty of an instructor's reviews per term or year, with rolling averages over the last few periods, to show whether an instructor got better or worse recently",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get instructor rating trend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instructor ID or name (e.g., alin, Angela_Lin, Angela Lin)",
+                        "name": "instructor_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period length (term or year, default term)",
+                        "name": "by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews of this course code (e.g., CMPT225)",
+                        "name": "course",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of periods in each rolling average (1-10, default 3)",
+                        "name": "window",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rating trend",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReviewTrend"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Instructor not found",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rest/reviews/quarantine": {
+            "get": {
+                "description": "Returns the course codes entered by reviewers that couldn't be resolved to a course of the outline catalog, most reviewed first. Reviews with these codes are left out of course reviews but stay on instructor reviews.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get quarantined review course codes",
+                "responses": {
+                    "200": {
+                        "description": "Unresolved course codes",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.QuarantinedCourseCode"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rest/search": {
+            "get": {
+                "description": "Full-text search over course codes, titles, designations, descriptions and notes. Words are stemmed and must all match; quoted phrases must match in order. Results are ranked by relevance, with title matches above description matches.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Outlines"
+                ],
+                "summary": "Search courses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query, with phrases in double quotes (e.g., machine learning, ethics)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Department code (e.g., cmpt, phil)",
+                        "name": "dept",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Course level (e.g., 300 for 3XX courses)",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only courses offered in a term, in format YYYY-Term (e.g., 2024-fall)",
+                        "name": "term",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-1000, default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of results to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching courses, most relevant first",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_CourseSearchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing query or invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/rest/sections": {
+            "get": {
+                "description": "Retrieves course sections for a specific year and term, optionally filtered by department and/or course number. With includeRatings=true, every instructor with reviews gets their ratings inline. With limit or offset, returns one page of courses in a paginated envelope instead of the full list.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sections"
+                ],
+                "summary": "Get sections",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Year and term in format YYYY-Term (e.g., 2024-spring)",
+                        "name": "term",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Department code (e.g., cmpt, math)",
+                        "name": "dept",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Course number (e.g., 120, 225)",
+                        "name": "number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Attach each instructor's review summary, including the averages of their reviews of the course (default false)",
+                        "name": "includeRatings",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size in courses (1-1000, default 100 when offset is set)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of courses to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of sections",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/model.CourseWithSectionDetails"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid year-term format or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No sections found for the specified criteria",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/rest/reviews/courses": {
+            "get": {
+                "description": "Returns summary review data for all courses. Course summaries are already numeric, so this matches the v1 response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get all course reviews overview (v2)",
+                "responses": {
+                    "200": {
+                        "description": "List of course summaries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CourseSummary"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/rest/reviews/courses/{course_code}": {
+            "get": {
+                "description": "Retrieves review data for a specific course code with numeric ratings, ISO dates and boolean review metadata. Values missing from RateMyProfessors are null. Accepts the same filter, sort and pagination parameters as the v1 endpoint.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get course reviews (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course code (e.g., CMPT353, BUS251)",
+                        "name": "course_code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews on or after this date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews on or before this date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews with one of these comma-separated grades (e.g., A+,A,A-)",
+                        "name": "grade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews with all of these comma-separated tags (e.g., Caring,Tough grader)",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews that would (yes) or would not (no) take the class again",
+                        "name": "wouldTakeAgain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews of online (yes) or in-person (no) classes",
+                        "name": "online",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by date, helpful, rating or difficulty (default: file order)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order, asc or desc (default desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of reviews (1-100, default unlimited)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of matching reviews to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Course review data",
+                        "schema": {
+                            "$ref": "#/definitions/model.CourseReviewDataV2"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or pagination parameter",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Course not found",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/rest/reviews/instructors": {
+            "get": {
+                "description": "Returns summary review data for all instructors with numeric ratings and percentages. Values missing from RateMyProfessors are null.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get all instructor reviews overview (v2)",
+                "responses": {
+                    "200": {
+                        "description": "List of instructor summaries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ProfessorSummaryV2"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/rest/reviews/instructors/{instructor_name}": {
+            "get": {
+                "description": "Retrieves detailed review data for a specific instructor with numeric ratings, ISO dates and boolean review metadata. Values missing from RateMyProfessors are null. Accepts the same filter, sort and pagination parameters as the v1 endpoint.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Get instructor reviews (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instructor ID or name (e.g., alin, Angela_Lin, Angela Lin)",
+                        "name": "instructor_name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews of this course code (e.g., CMPT225)",
+                        "name": "course",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews on or after this date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews on or before this date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews with one of these comma-separated grades (e.g., A+,A,A-)",
+                        "name": "grade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews with all of these comma-separated tags (e.g., Caring,Tough grader)",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews that would (yes) or would not (no) take the class again",
+                        "name": "wouldTakeAgain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews of online (yes) or in-person (no) classes",
+                        "name": "online",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort by date, helpful, rating or difficulty (default: file order)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order, asc or desc (default desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of reviews (1-100, default unlimited)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of matching reviews to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Instructor review data",
+                        "schema": {
+                            "$ref": "#/definitions/model.InstructorReviewDataV2"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or pagination parameter",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Instructor not found",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
     "definitions": {
         "main.ErrorResponse": {
             "description": "Error information",
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.HealthResponse": {
+            "description": "Health check status information",
+            "type": "object",
+            "properties": {
+                "lastDataUpdate": {
+                    "type": "string",
+                    "example": "2025-06-17T07:40:46Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                }
+            }
+        },
+        "main.graphQLRequest": {
+            "type": "object",
+            "properties": {
+                "extensions": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "model.AllCourseOutlinesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CourseOutline"
+                    }
+                },
+                "next_url": {
+                    "type": "string",
+                    "example": "/v1/rest/outlines?limit=100\u0026offset=200"
+                },
+                "prev_url": {
+                    "type": "string",
+                    "example": "/v1/rest/outlines?limit=100\u0026offset=0"
+                },
+                "total_count": {
+                    "type": "integer",
+                    "example": 3412
+                }
+            }
+        },
+        "model.CampusHeatmap": {
+            "description": "Scheduled class-hours of a single campus by weekday and time slot",
+            "type": "object",
+            "properties": {
+                "campus": {
+                    "type": "string",
+                    "example": "Burnaby"
+                },
+                "peakSlot": {
+                    "$ref": "#/definitions/model.HeatmapSlot"
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HeatmapSlot"
+                    }
+                },
+                "totalClassHours": {
+                    "type": "number",
+                    "example": 5123.5
+                }
+            }
+        },
+        "model.CourseOffering": {
+            "description": "Course offering information for a specific term",
+            "type": "object",
+            "properties": {
+                "instructors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "John Doe",
+                        "Jane Smith"
+                    ]
+                },
+                "term": {
+                    "type": "string",
+                    "example": "Fall 2024"
+                }
+            }
+        },
+        "model.CourseOutline": {
+            "description": "Course outline information including general details and offerings",
+            "type": "object",
+            "properties": {
+                "corequisites": {
+                    "type": "string",
+                    "example": ""
+                },
+                "degreeLevel": {
+                    "type": "string",
+                    "example": "UGRD"
+                },
+                "deliveryMethod": {
+                    "type": "string",
+                    "example": "In Person"
+                },
+                "dept": {
+                    "type": "string",
+                    "example": "CMPT"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Introduction to a variety of practical and important data structures and methods..."
+                },
+                "designation": {
+                    "type": "string",
+                    "example": "Quantitative"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": ""
+                },
+                "number": {
+                    "type": "string",
+                    "example": "225"
+                },
+                "offerings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CourseOffering"
+                    }
+                },
+                "prerequisites": {
+                    "type": "string",
+                    "example": "(MACM 101 and (CMPT 125, CMPT 129 or CMPT 135)) or (ENSC 251 and ENSC 252), all with a minimum grade of C-."
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Data Structures and Programming"
+                },
+                "units": {
+                    "type": "string",
+                    "example": "3"
+                }
+            }
+        },
+        "model.CourseReviewData": {
+            "description": "Course review data aggregated from instructors",
+            "type": "object",
+            "properties": {
+                "avg_difficulty": {
+                    "type": "number",
+                    "example": 3.1
+                },
+                "avg_rating": {
+                    "type": "number",
+                    "example": 3.8
+                },
+                "course_code": {
+                    "type": "string",
+                    "example": "BUS251"
+                },
+                "instructors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InstructorSummary"
+                    }
+                },
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Keyword"
+                    }
+                },
+                "total_reviews": {
+                    "type": "integer",
+                    "example": 25
+                }
+            }
+        },
+        "model.CourseReviewDataV2": {
+            "description": "Course review data aggregated from instructors",
+            "type": "object",
+            "properties": {
+                "avg_difficulty": {
+                    "type": "number",
+                    "example": 3.1
+                },
+                "avg_rating": {
+                    "type": "number",
+                    "example": 3.8
+                },
+                "course_code": {
+                    "type": "string",
+                    "example": "BUS251"
+                },
+                "instructors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InstructorSummaryV2"
+                    }
+                },
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Keyword"
+                    }
+                },
+                "total_reviews": {
+                    "type": "integer",
+                    "example": 25
+                }
+            }
+        },
+        "model.CourseSearchResult": {
+            "description": "Course outline matching a search query, with its relevance score",
+            "type": "object",
+            "properties": {
+                "dept": {
+                    "type": "string",
+                    "example": "CMPT"
+                },
+                "matchedFields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "title",
+                        "description"
+                    ]
+                },
+                "number": {
+                    "type": "string",
+                    "example": "225"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 12.48
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Data Structures and Programming"
+                },
+                "units": {
+                    "type": "string",
+                    "example": "3"
+                }
+            }
+        },
+        "model.CourseSummary": {
+            "description": "Summary review information for a course",
+            "type": "object",
+            "properties": {
+                "avg_difficulty": {
+                    "type": "number",
+                    "example": 2
+                },
+                "avg_rating": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "course_code": {
+                    "type": "string",
+                    "example": "SOC225"
+                },
+                "total_reviews": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "model.CourseWithSectionDetails": {
+            "description": "Course with detailed section information",
+            "type": "object",
+            "properties": {
+                "dept": {
+                    "type": "string",
+                    "example": "CMPT"
+                },
+                "number": {
+                    "type": "string",
+                    "example": "225"
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SectionDetail"
+                    }
+                },
+                "term": {
+                    "type": "string",
+                    "example": "Fall 2024"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Data Structure and Algorithms"
+                },
+                "units": {
+                    "type": "string",
+                    "example": "3"
+                }
+            }
+        },
+        "model.GraphEdge": {
+            "description": "Taught edge from an instructor to a course, or co-taught edge between two instructors",
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "CMPT 225"
+                    ]
+                },
+                "source": {
+                    "type": "string",
+                    "example": "instructor:jdoe"
+                },
+                "target": {
+                    "type": "string",
+                    "example": "course:CMPT 225"
+                },
+                "terms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Fall 2024",
+                        "Spring 2025"
+                    ]
+                },
+                "type": {
+                    "type": "string",
+                    "example": "taught"
+                },
+                "weight": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.GraphNode": {
+            "description": "Instructor or course node",
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "instructor:jdoe"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Data Structures and Programming"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "instructor"
+                }
+            }
+        },
+        "model.HeatmapSlot": {
+            "description": "Scheduled class-hours within one weekday time slot",
+            "type": "object",
+            "properties": {
+                "classHours": {
+                    "type": "number",
+                    "example": 42.5
+                },
+                "day": {
+                    "type": "string",
+                    "example": "Mo"
+                },
+                "endTime": {
+                    "type": "string",
+                    "example": "11:00"
+                },
+                "sections": {
+                    "type": "integer",
+                    "example": 85
+                },
+                "startTime": {
+                    "type": "string",
+                    "example": "10:30"
+                }
+            }
+        },
+        "model.HistogramBucket": {
+            "description": "Histogram bucket",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "value": {
+                    "type": "number",
+                    "example": 4.5
+                }
+            }
+        },
+        "model.Instructor": {
+            "description": "Instructor information",
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john_doe@sfu.ca"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "jdoe"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "ratings": {
+                    "$ref": "#/definitions/model.InstructorRatings"
+                }
+            }
+        },
+        "model.InstructorCourseStats": {
+            "description": "Teaching statistics of an instructor for a course",
+            "type": "object",
+            "properties": {
+                "dept": {
+                    "type": "string",
+                    "example": "CMPT"
                 },
-                "message": {
-                    "type": "string"
+                "firstTerm": {
+                    "type": "string",
+                    "example": "Spring 2024"
+                },
+                "lastTerm": {
+                    "type": "string",
+                    "example": "Fall 2025"
+                },
+                "number": {
+                    "type": "string",
+                    "example": "225"
+                },
+                "offerings": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "sections": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Data Structures and Programming"
                 }
             }
         },
-        "main.HealthResponse": {
-            "description": "Health check status information",
+        "model.InstructorDeptStats": {
+            "description": "Teaching statistics of an instructor in a department",
             "type": "object",
             "properties": {
-                "lastDataUpdate": {
+                "courses": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "dept": {
                     "type": "string",
-                    "example": "2025-06-17T07:40:46Z"
+                    "example": "CMPT"
                 },
-                "status": {
+                "offerings": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "sections": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "model.InstructorOffering": {
+            "description": "Instructor offering information",
+            "type": "object",
+            "properties": {
+                "dept": {
                     "type": "string",
-                    "example": "ok"
+                    "example": "CMPT"
                 },
-                "version": {
+                "number": {
                     "type": "string",
-                    "example": "1.0.0"
+                    "example": "225"
+                },
+                "term": {
+                    "type": "string",
+                    "example": "Fall 2024"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Data Structures and Algorithms"
                 }
             }
         },
-        "model.CourseOffering": {
-            "description": "Course offering information for a specific term",
+        "model.InstructorProfile": {
+            "description": "Instructor profile joining teaching history, current sections, contact and review summary",
             "type": "object",
             "properties": {
-                "instructors": {
+                "aliases": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     },
                     "example": [
                         "John Doe",
-                        "Jane Smith"
+                        "John A. Doe"
                     ]
                 },
-                "term": {
+                "currentSections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InstructorSection"
+                    }
+                },
+                "currentTerm": {
                     "type": "string",
                     "example": "Fall 2024"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "john_doe@sfu.ca"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "jdoe"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "reviews": {
+                    "$ref": "#/definitions/model.InstructorReviewSummary"
+                },
+                "teachingHistory": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InstructorTermHistory"
+                    }
                 }
             }
         },
-        "model.CourseOutline": {
-            "description": "Course outline information including general details and offerings",
+        "model.InstructorRatings": {
+            "description": "Instructor review summary from RateMyProfessors; values missing from RateMyProfessors are null",
             "type": "object",
             "properties": {
-                "corequisites": {
+                "avgDifficulty": {
+                    "type": "number",
+                    "example": 3.5
+                },
+                "avgRating": {
+                    "type": "number",
+                    "example": 4.2
+                },
+                "courseAvgDifficulty": {
+                    "type": "number",
+                    "example": 3
+                },
+                "courseAvgRating": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "courseReviewCount": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "reviewCount": {
+                    "type": "integer",
+                    "example": 13
+                },
+                "wouldTakeAgain": {
+                    "type": "number",
+                    "example": 85
+                }
+            }
+        },
+        "model.InstructorResponse": {
+            "description": "Instructor information",
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "John Doe",
+                        "John A. Doe"
+                    ]
+                },
+                "id": {
                     "type": "string",
-                    "example": ""
+                    "example": "jdoe"
                 },
-                "degreeLevel": {
+                "name": {
                     "type": "string",
-                    "example": "UGRD"
+                    "example": "John Doe"
                 },
-                "deliveryMethod": {
+                "offerings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InstructorOffering"
+                    }
+                }
+            }
+        },
+        "model.InstructorReviewData": {
+            "description": "Instructor review data from RateMyProfessors",
+            "type": "object",
+            "properties": {
+                "department": {
                     "type": "string",
-                    "example": "In Person"
+                    "example": "Accounting department"
                 },
-                "dept": {
+                "difficulty_level": {
                     "type": "string",
-                    "example": "CMPT"
+                    "example": "3.5"
                 },
-                "description": {
+                "instructor_id": {
                     "type": "string",
-                    "example": "Introduction to a variety of practical and important data structures and methods..."
+                    "example": "alin"
                 },
-                "designation": {
+                "keywords": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Keyword"
+                    }
+                },
+                "overall_rating": {
                     "type": "string",
-                    "example": "Quantitative"
+                    "example": "4.2"
                 },
-                "notes": {
+                "page": {
+                    "$ref": "#/definitions/model.ReviewPage"
+                },
+                "professor_id": {
                     "type": "string",
-                    "example": ""
+                    "example": "2326813"
                 },
-                "number": {
+                "professor_name": {
                     "type": "string",
-                    "example": "225"
+                    "example": "Angela Lin"
                 },
-                "offerings": {
+                "reviews": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.CourseOffering"
+                        "$ref": "#/definitions/model.Review"
                     }
                 },
-                "prerequisites": {
-                    "type": "string",
-                    "example": "(MACM 101 and (CMPT 125, CMPT 129 or CMPT 135)) or (ENSC 251 and ENSC 252), all with a minimum grade of C-."
-                },
-                "title": {
+                "total_ratings": {
                     "type": "string",
-                    "example": "Data Structures and Programming"
+                    "example": "13"
                 },
-                "units": {
+                "would_take_again": {
                     "type": "string",
-                    "example": "3"
+                    "example": "85"
                 }
             }
         },
-        "model.CourseReviewData": {
-            "description": "Course review data aggregated from instructors",
+        "model.InstructorReviewDataV2": {
+            "description": "Instructor review data from RateMyProfessors; values missing from RateMyProfessors are null",
             "type": "object",
             "properties": {
-                "course_code": {
+                "department": {
                     "type": "string",
-                    "example": "BUS251"
+                    "example": "Accounting department"
                 },
-                "instructors": {
+                "difficulty_level": {
+                    "type": "number",
+                    "example": 3.5
+                },
+                "instructor_id": {
+                    "type": "string",
+                    "example": "alin"
+                },
+                "keywords": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.InstructorSummary"
+                        "$ref": "#/definitions/model.Keyword"
                     }
                 },
-                "total_reviews": {
-                    "type": "integer",
-                    "example": 25
-                }
-            }
-        },
-        "model.CourseSummary": {
-            "description": "Summary review information for a course",
-            "type": "object",
-            "properties": {
-                "avg_difficulty": {
+                "overall_rating": {
                     "type": "number",
-                    "example": 2
+                    "example": 4.2
                 },
-                "avg_rating": {
-                    "type": "number",
-                    "example": 4.5
+                "page": {
+                    "$ref": "#/definitions/model.ReviewPage"
                 },
-                "course_code": {
+                "professor_id": {
                     "type": "string",
-                    "example": "SOC225"
+                    "example": "2326813"
                 },
-                "total_reviews": {
+                "professor_name": {
+                    "type": "string",
+                    "example": "Angela Lin"
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReviewV2"
+                    }
+                },
+                "total_ratings": {
                     "type": "integer",
-                    "example": 2
+                    "example": 13
+                },
+                "would_take_again": {
+                    "type": "number",
+                    "example": 85
                 }
             }
         },
-        "model.CourseWithSectionDetails": {
-            "description": "Course with detailed section information",
+        "model.InstructorReviewSummary": {
+            "description": "Instructor review summary from RateMyProfessors",
             "type": "object",
             "properties": {
-                "dept": {
+                "department": {
                     "type": "string",
-                    "example": "CMPT"
+                    "example": "Accounting department"
                 },
-                "number": {
+                "difficulty_level": {
                     "type": "string",
-                    "example": "225"
+                    "example": "3.5"
                 },
-                "sections": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.SectionDetail"
-                    }
+                "overall_rating": {
+                    "type": "string",
+                    "example": "4.2"
                 },
-                "term": {
+                "professor_id": {
                     "type": "string",
-                    "example": "Fall 2024"
+                    "example": "2326813"
                 },
-                "title": {
+                "total_ratings": {
                     "type": "string",
-                    "example": "Data Structure and Algorithms"
+                    "example": "13"
                 },
-                "units": {
+                "would_take_again": {
                     "type": "string",
-                    "example": "3"
+                    "example": "85"
                 }
             }
         },
-        "model.Instructor": {
-            "description": "Instructor information",
+        "model.InstructorSearchResult": {
+            "description": "Instructor search result, ranked by relevance",
             "type": "object",
             "properties": {
-                "email": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "John Doe",
+                        "John A. Doe"
+                    ]
+                },
+                "id": {
                     "type": "string",
-                    "example": "john_doe@sfu.ca"
+                    "example": "jdoe"
+                },
+                "matchedName": {
+                    "type": "string",
+                    "example": "John A. Doe"
                 },
                 "name": {
                     "type": "string",
                     "example": "John Doe"
+                },
+                "offerings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InstructorOffering"
+                    }
+                },
+                "score": {
+                    "type": "number",
+                    "example": 1.5
                 }
             }
         },
-        "model.InstructorOffering": {
-            "description": "Instructor offering information",
+        "model.InstructorSection": {
+            "description": "Section taught by an instructor, including its schedules",
             "type": "object",
             "properties": {
+                "classNumber": {
+                    "type": "string",
+                    "example": "6327"
+                },
+                "deliveryMethod": {
+                    "type": "string",
+                    "example": "In Person"
+                },
                 "dept": {
                     "type": "string",
                     "example": "CMPT"
@@ -644,6 +2651,16 @@ const docTemplate = `{
                     "type": "string",
                     "example": "225"
                 },
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SectionSchedule"
+                    }
+                },
+                "section": {
+                    "type": "string",
+                    "example": "D100"
+                },
                 "term": {
                     "type": "string",
                     "example": "Fall 2024"
@@ -654,37 +2671,94 @@ const docTemplate = `{
                 }
             }
         },
-        "model.InstructorResponse": {
-            "description": "Instructor information",
+        "model.InstructorStats": {
+            "description": "Teaching statistics of an instructor across all known terms",
             "type": "object",
             "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InstructorCourseStats"
+                    }
+                },
+                "departments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InstructorDeptStats"
+                    }
+                },
+                "firstTerm": {
+                    "type": "string",
+                    "example": "Spring 2024"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "jdoe"
+                },
+                "labSections": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "lastTerm": {
+                    "type": "string",
+                    "example": "Fall 2025"
+                },
+                "lectureSections": {
+                    "type": "integer",
+                    "example": 9
+                },
                 "name": {
                     "type": "string",
                     "example": "John Doe"
                 },
-                "offerings": {
+                "sectionTypes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "terms": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.InstructorOffering"
+                        "$ref": "#/definitions/model.InstructorTermStats"
                     }
+                },
+                "termsTaught": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "totalOfferings": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "totalSections": {
+                    "type": "integer",
+                    "example": 14
                 }
             }
         },
-        "model.InstructorReviewData": {
-            "description": "Instructor review data from RateMyProfessors",
+        "model.InstructorSummary": {
+            "description": "Summary information for an instructor",
             "type": "object",
             "properties": {
+                "avg_difficulty": {
+                    "type": "number",
+                    "example": 3.5
+                },
+                "avg_rating": {
+                    "type": "number",
+                    "example": 4.2
+                },
                 "department": {
                     "type": "string",
-                    "example": "Accounting department"
+                    "example": "Accounting"
                 },
-                "difficulty_level": {
+                "instructor_id": {
                     "type": "string",
-                    "example": "3.5"
+                    "example": "alin"
                 },
-                "overall_rating": {
-                    "type": "string",
-                    "example": "4.2"
+                "page": {
+                    "$ref": "#/definitions/model.ReviewPage"
                 },
                 "professor_id": {
                     "type": "string",
@@ -694,24 +2768,24 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Angela Lin"
                 },
+                "review_count": {
+                    "type": "integer",
+                    "example": 13
+                },
                 "reviews": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/model.Review"
                     }
                 },
-                "total_ratings": {
-                    "type": "string",
-                    "example": "13"
-                },
                 "would_take_again": {
                     "type": "string",
-                    "example": "85"
+                    "example": "85%"
                 }
             }
         },
-        "model.InstructorSummary": {
-            "description": "Summary information for an instructor",
+        "model.InstructorSummaryV2": {
+            "description": "Summary information for an instructor; values missing from RateMyProfessors are null",
             "type": "object",
             "properties": {
                 "avg_difficulty": {
@@ -726,6 +2800,13 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Accounting"
                 },
+                "instructor_id": {
+                    "type": "string",
+                    "example": "alin"
+                },
+                "page": {
+                    "$ref": "#/definitions/model.ReviewPage"
+                },
                 "professor_id": {
                     "type": "string",
                     "example": "2326813"
@@ -741,12 +2822,165 @@ const docTemplate = `{
                 "reviews": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Review"
+                        "$ref": "#/definitions/model.ReviewV2"
                     }
                 },
                 "would_take_again": {
+                    "type": "number",
+                    "example": 85
+                }
+            }
+        },
+        "model.InstructorTermHistory": {
+            "description": "Courses taught by an instructor in a single term",
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InstructorOffering"
+                    }
+                },
+                "term": {
                     "type": "string",
-                    "example": "85%"
+                    "example": "Fall 2024"
+                }
+            }
+        },
+        "model.InstructorTermStats": {
+            "description": "Courses and sections taught by an instructor in a single term",
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "CMPT 225",
+                        "CMPT 307"
+                    ]
+                },
+                "labSections": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lectureSections": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sections": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "term": {
+                    "type": "string",
+                    "example": "Fall 2024"
+                }
+            }
+        },
+        "model.Keyword": {
+            "description": "Phrase scored by TF-IDF against all reviews",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "phrase": {
+                    "type": "string",
+                    "example": "curved exams"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.0842
+                }
+            }
+        },
+        "model.LabelCount": {
+            "description": "Label frequency",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "label": {
+                    "type": "string",
+                    "example": "A-"
+                },
+                "percent": {
+                    "type": "number",
+                    "example": 19.05
+                }
+            }
+        },
+        "model.PaginatedResponse-model_CourseSearchResult": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CourseSearchResult"
+                    }
+                },
+                "next_url": {
+                    "type": "string",
+                    "example": "/v1/rest/outlines?limit=100\u0026offset=200"
+                },
+                "prev_url": {
+                    "type": "string",
+                    "example": "/v1/rest/outlines?limit=100\u0026offset=0"
+                },
+                "total_count": {
+                    "type": "integer",
+                    "example": 3412
+                }
+            }
+        },
+        "model.PaginatedResponse-model_CourseWithSectionDetails": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CourseWithSectionDetails"
+                    }
+                },
+                "next_url": {
+                    "type": "string",
+                    "example": "/v1/rest/outlines?limit=100\u0026offset=200"
+                },
+                "prev_url": {
+                    "type": "string",
+                    "example": "/v1/rest/outlines?limit=100\u0026offset=0"
+                },
+                "total_count": {
+                    "type": "integer",
+                    "example": 3412
+                }
+            }
+        },
+        "model.PaginatedResponse-model_InstructorResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InstructorResponse"
+                    }
+                },
+                "next_url": {
+                    "type": "string",
+                    "example": "/v1/rest/outlines?limit=100\u0026offset=200"
+                },
+                "prev_url": {
+                    "type": "string",
+                    "example": "/v1/rest/outlines?limit=100\u0026offset=0"
+                },
+                "total_count": {
+                    "type": "integer",
+                    "example": 3412
                 }
             }
         },
@@ -783,29 +3017,112 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Gender Studies"
                 },
-                "Difficulty": {
+                "Difficulty": {
+                    "type": "string",
+                    "example": "3.6"
+                },
+                "Name": {
+                    "type": "string",
+                    "example": "Carman Fung"
+                },
+                "Quality": {
+                    "type": "string",
+                    "example": "3.1"
+                },
+                "Ratings": {
+                    "type": "string",
+                    "example": "14"
+                },
+                "URL": {
+                    "type": "string",
+                    "example": "https://www.ratemyprofessors.com/professor/2865715"
+                },
+                "WouldTakeAgain": {
+                    "type": "string",
+                    "example": "50%"
+                },
+                "instructor_id": {
+                    "type": "string",
+                    "example": "cfung"
+                }
+            }
+        },
+        "model.ProfessorSummaryV2": {
+            "description": "Summary review information for a professor; values missing from RateMyProfessors are null",
+            "type": "object",
+            "properties": {
+                "department": {
+                    "type": "string",
+                    "example": "Gender Studies"
+                },
+                "difficulty": {
+                    "type": "number",
+                    "example": 3.6
+                },
+                "instructor_id": {
                     "type": "string",
-                    "example": "3.6"
+                    "example": "cfung"
                 },
-                "Name": {
+                "name": {
                     "type": "string",
                     "example": "Carman Fung"
                 },
-                "Quality": {
-                    "type": "string",
-                    "example": "3.1"
+                "quality": {
+                    "type": "number",
+                    "example": 3.1
                 },
-                "Ratings": {
-                    "type": "string",
-                    "example": "14"
+                "ratings": {
+                    "type": "integer",
+                    "example": 14
                 },
-                "URL": {
+                "url": {
                     "type": "string",
                     "example": "https://www.ratemyprofessors.com/professor/2865715"
                 },
-                "WouldTakeAgain": {
+                "would_take_again": {
+                    "type": "number",
+                    "example": 50
+                }
+            }
+        },
+        "model.QuarantinedCourseCode": {
+            "description": "Review course code left out of course reviews",
+            "type": "object",
+            "properties": {
+                "course_code": {
                     "type": "string",
-                    "example": "50%"
+                    "example": "PHILXX1"
+                },
+                "instructors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Angela Lin"
+                    ]
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "malformed"
+                },
+                "review_count": {
+                    "type": "integer",
+                    "example": 132
+                }
+            }
+        },
+        "model.RateStat": {
+            "description": "Share of yes answers among the reviews that answered",
+            "type": "object",
+            "properties": {
+                "answered": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "rate": {
+                    "type": "number",
+                    "example": 0.62
                 }
             }
         },
@@ -817,10 +3134,18 @@ const docTemplate = `{
                     "type": "string",
                     "example": "BUS251"
                 },
+                "course_code_raw": {
+                    "type": "string",
+                    "example": "bus-251"
+                },
                 "date": {
                     "type": "string",
                     "example": "Sep 1st, 2020"
                 },
+                "date_iso": {
+                    "type": "string",
+                    "example": "2020-09-01"
+                },
                 "difficulty": {
                     "type": "string",
                     "example": "3.0"
@@ -886,6 +3211,187 @@ const docTemplate = `{
                 }
             }
         },
+        "model.ReviewPage": {
+            "description": "Pagination of a list of reviews",
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "model.ReviewStats": {
+            "description": "Rating, difficulty, grade and tag distributions of a set of reviews",
+            "type": "object",
+            "properties": {
+                "attendance_mandatory_rate": {
+                    "$ref": "#/definitions/model.RateStat"
+                },
+                "avg_difficulty": {
+                    "type": "number",
+                    "example": 3.1
+                },
+                "avg_rating": {
+                    "type": "number",
+                    "example": 4.2
+                },
+                "course_code": {
+                    "type": "string",
+                    "example": "CMPT225"
+                },
+                "difficulty_histogram": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HistogramBucket"
+                    }
+                },
+                "grade_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LabelCount"
+                    }
+                },
+                "instructor_id": {
+                    "type": "string",
+                    "example": "alin"
+                },
+                "professor_name": {
+                    "type": "string",
+                    "example": "Angela Lin"
+                },
+                "rating_histogram": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HistogramBucket"
+                    }
+                },
+                "textbook_rate": {
+                    "$ref": "#/definitions/model.RateStat"
+                },
+                "top_tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LabelCount"
+                    }
+                },
+                "total_reviews": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "would_take_again_rate": {
+                    "$ref": "#/definitions/model.RateStat"
+                }
+            }
+        },
+        "model.ReviewTrend": {
+            "description": "Average and rolling average rating and difficulty per term or year",
+            "type": "object",
+            "properties": {
+                "course_code": {
+                    "type": "string",
+                    "example": "CMPT225"
+                },
+                "instructor_id": {
+                    "type": "string",
+                    "example": "alin"
+                },
+                "period": {
+                    "type": "string",
+                    "example": "term"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TrendPoint"
+                    }
+                },
+                "professor_name": {
+                    "type": "string",
+                    "example": "Angela Lin"
+                },
+                "window": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.ReviewV2": {
+            "description": "Detailed review information; values missing from RateMyProfessors are null",
+            "type": "object",
+            "properties": {
+                "attendance_mandatory": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "course_code": {
+                    "type": "string",
+                    "example": "BUS251"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2020-09-01"
+                },
+                "difficulty": {
+                    "type": "number",
+                    "example": 3
+                },
+                "for_credit": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "grade": {
+                    "type": "string",
+                    "example": "B+"
+                },
+                "helpful": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "not_helpful": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "online_class": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 4
+                },
+                "review_msg": {
+                    "type": "string",
+                    "example": "Great professor!"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Caring",
+                        "Tough grader"
+                    ]
+                },
+                "textbook": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "would_take_again": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
         "model.SectionDetail": {
             "description": "Detailed information about a course section",
             "type": "object",
@@ -949,6 +3455,119 @@ const docTemplate = `{
                     "example": "10:30"
                 }
             }
+        },
+        "model.TeachingGraph": {
+            "description": "Graph of instructors and courses, with \"taught\" and \"co-taught\" edges weighted by number of terms",
+            "type": "object",
+            "properties": {
+                "edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GraphEdge"
+                    }
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GraphNode"
+                    }
+                }
+            }
+        },
+        "model.TeamTaughtCourse": {
+            "description": "Course with at least one section taught by several instructors",
+            "type": "object",
+            "properties": {
+                "dept": {
+                    "type": "string",
+                    "example": "CMPT"
+                },
+                "instructors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "John Doe",
+                        "Jane Smith"
+                    ]
+                },
+                "number": {
+                    "type": "string",
+                    "example": "225"
+                },
+                "terms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Fall 2024"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Data Structures and Programming"
+                }
+            }
+        },
+        "model.TimetableHeatmap": {
+            "description": "Weekly class-hours per weekday and time slot for a term, grouped by campus",
+            "type": "object",
+            "properties": {
+                "campuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CampusHeatmap"
+                    }
+                },
+                "dept": {
+                    "type": "string",
+                    "example": "CMPT"
+                },
+                "sectionCode": {
+                    "type": "string",
+                    "example": "LEC"
+                },
+                "slotMinutes": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "term": {
+                    "type": "string",
+                    "example": "2025-fall"
+                }
+            }
+        },
+        "model.TrendPoint": {
+            "description": "Reviews of one period",
+            "type": "object",
+            "properties": {
+                "avg_difficulty": {
+                    "type": "number",
+                    "example": 3
+                },
+                "avg_rating": {
+                    "type": "number",
+                    "example": 4.25
+                },
+                "period": {
+                    "type": "string",
+                    "example": "2024-fall"
+                },
+                "reviews": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "rolling_difficulty": {
+                    "type": "number",
+                    "example": 3.2
+                },
+                "rolling_rating": {
+                    "type": "number",
+                    "example": 4.1
+                }
+            }
         }
     },
     "tags": [
@@ -963,6 +3582,18 @@ const docTemplate = `{
         {
             "description": "Section endpoints for retrieving section info, including its schedules and instructor(s)",
             "name": "Sections"
+        },
+        {
+            "description": "Analytics endpoints for aggregated views over sections, such as timetable utilization",
+            "name": "Analytics"
+        },
+        {
+            "description": "Graph endpoints relating instructors and the courses they taught, including co-teaching",
+            "name": "Graph"
+        },
+        {
+            "description": "GraphQL endpoint linking courses to their offerings, sections, instructors and reviews",
+            "name": "GraphQL"
         }
     ]
 }`
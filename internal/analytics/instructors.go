@@ -0,0 +1,163 @@
+package analytics
+
+import (
+	"sort"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/brianrahadi/sfucourses-api/internal/utils"
+)
+
+// unknownSectionType labels sections without any non-exam schedule.
+const unknownSectionType = "Unknown"
+
+type courseKey struct {
+	dept   string
+	number string
+}
+
+// BuildInstructorStats summarizes what an instructor taught and how often.
+// Offerings come from the instructor list and sections from the per-term
+// section files, so a course offering without matching sections still counts
+// as an offering.
+func BuildInstructorStats(instructor model.InstructorResponse, sections []model.InstructorSection) model.InstructorStats {
+	stats := model.InstructorStats{
+		ID:           instructor.ID,
+		Name:         instructor.Name,
+		SectionTypes: make(map[string]int),
+		Departments:  []model.InstructorDeptStats{},
+		Courses:      []model.InstructorCourseStats{},
+		Terms:        []model.InstructorTermStats{},
+	}
+
+	terms := make(map[string]*model.InstructorTermStats)
+	courses := make(map[courseKey]*model.InstructorCourseStats)
+	depts := make(map[string]*model.InstructorDeptStats)
+	seenOfferings := make(map[string]bool)
+
+	termStats := func(term string) *model.InstructorTermStats {
+		if terms[term] == nil {
+			terms[term] = &model.InstructorTermStats{Term: term, Courses: []string{}}
+		}
+		return terms[term]
+	}
+	deptStats := func(dept string) *model.InstructorDeptStats {
+		if depts[dept] == nil {
+			depts[dept] = &model.InstructorDeptStats{Dept: dept}
+		}
+		return depts[dept]
+	}
+
+	for _, offering := range instructor.Offerings {
+		offeringKey := offering.Term + " " + offering.Dept + " " + offering.Number
+		if seenOfferings[offeringKey] {
+			continue
+		}
+		seenOfferings[offeringKey] = true
+		stats.TotalOfferings++
+
+		term := termStats(offering.Term)
+		term.Courses = append(term.Courses, offering.Dept+" "+offering.Number)
+
+		key := courseKey{offering.Dept, offering.Number}
+		course := courses[key]
+		if course == nil {
+			course = &model.InstructorCourseStats{
+				Dept:      offering.Dept,
+				Number:    offering.Number,
+				Title:     offering.Title,
+				FirstTerm: offering.Term,
+				LastTerm:  offering.Term,
+			}
+			courses[key] = course
+			deptStats(offering.Dept).Courses++
+		}
+		course.Offerings++
+		if termBefore(offering.Term, course.FirstTerm) {
+			course.FirstTerm = offering.Term
+		}
+		if termBefore(course.LastTerm, offering.Term) {
+			course.LastTerm = offering.Term
+			course.Title = offering.Title
+		}
+		deptStats(offering.Dept).Offerings++
+	}
+
+	for _, section := range sections {
+		sectionType := SectionType(section.Schedules)
+		stats.TotalSections++
+		stats.SectionTypes[sectionType]++
+
+		term := termStats(section.Term)
+		term.Sections++
+		switch sectionType {
+		case "LEC":
+			stats.LectureSections++
+			term.LectureSections++
+		case "LAB":
+			stats.LabSections++
+			term.LabSections++
+		}
+
+		if course := courses[courseKey{section.Dept, section.Number}]; course != nil {
+			course.Sections++
+		}
+		deptStats(section.Dept).Sections++
+	}
+
+	for _, term := range terms {
+		sort.Strings(term.Courses)
+		stats.Terms = append(stats.Terms, *term)
+	}
+	sort.Slice(stats.Terms, func(i, j int) bool {
+		return termBefore(stats.Terms[i].Term, stats.Terms[j].Term)
+	})
+	stats.TermsTaught = len(stats.Terms)
+	if len(stats.Terms) > 0 {
+		stats.FirstTerm = stats.Terms[0].Term
+		stats.LastTerm = stats.Terms[len(stats.Terms)-1].Term
+	}
+
+	for _, course := range courses {
+		stats.Courses = append(stats.Courses, *course)
+	}
+	sort.Slice(stats.Courses, func(i, j int) bool {
+		ci, cj := stats.Courses[i], stats.Courses[j]
+		if ci.Offerings != cj.Offerings {
+			return ci.Offerings > cj.Offerings
+		}
+		if ci.Dept != cj.Dept {
+			return ci.Dept < cj.Dept
+		}
+		return ci.Number < cj.Number
+	})
+
+	for _, dept := range depts {
+		stats.Departments = append(stats.Departments, *dept)
+	}
+	sort.Slice(stats.Departments, func(i, j int) bool {
+		di, dj := stats.Departments[i], stats.Departments[j]
+		if di.Offerings != dj.Offerings {
+			return di.Offerings > dj.Offerings
+		}
+		return di.Dept < dj.Dept
+	})
+
+	return stats
+}
+
+// SectionType returns the section code of the first non-exam schedule of a
+// section, such as LEC, LAB or TUT.
+// Returns "Unknown" if the section has no such schedule.
+func SectionType(schedules []model.SectionSchedule) string {
+	for _, schedule := range schedules {
+		if schedule.SectionCode != "" && schedule.SectionCode != examSectionCode {
+			return schedule.SectionCode
+		}
+	}
+	return unknownSectionType
+}
+
+// termBefore reports whether display term a comes before display term b.
+func termBefore(a, b string) bool {
+	return utils.TermSortKey(utils.TermCodeFromTerm(a)) < utils.TermSortKey(utils.TermCodeFromTerm(b))
+}
@@ -0,0 +1,216 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+)
+
+// SlotMinutes is the length of a single heatmap time slot.
+const SlotMinutes = 30
+
+// weekdays lists the day codes used by SectionSchedule.Days in calendar order.
+var weekdays = []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"}
+
+// unknownCampus labels schedules that have meeting times but no campus.
+const unknownCampus = "Unknown"
+
+// examSectionCode is the section code of final exam schedules.
+const examSectionCode = "EXM"
+
+// TimetableOptions narrows down which schedules are counted in a heatmap.
+// Empty fields match everything.
+type TimetableOptions struct {
+	Campus      string
+	SectionCode string
+}
+
+type slotKey struct {
+	day  int
+	slot int
+}
+
+type campusAccumulator struct {
+	hours    map[slotKey]float64
+	sections map[slotKey]map[string]struct{}
+}
+
+// BuildTimetableHeatmap aggregates the weekly class-hours of every scheduled
+// meeting into weekday/time slots for each campus. Exam schedules are skipped
+// unless they are requested explicitly through opts.SectionCode.
+func BuildTimetableHeatmap(courses []model.CourseWithSectionDetails, opts TimetableOptions) []model.CampusHeatmap {
+	campuses := make(map[string]*campusAccumulator)
+
+	for _, course := range courses {
+		for _, section := range course.SectionDetails {
+			sectionID := course.Dept + " " + course.Number + " " + section.Section
+
+			for _, schedule := range section.Schedules {
+				if !matchesSchedule(schedule, opts) {
+					continue
+				}
+
+				start, okStart := parseClock(schedule.StartTime)
+				end, okEnd := parseClock(schedule.EndTime)
+				if !okStart || !okEnd || end <= start {
+					continue
+				}
+
+				campus := strings.TrimSpace(schedule.Campus)
+				if campus == "" {
+					campus = unknownCampus
+				}
+
+				acc, ok := campuses[campus]
+				if !ok {
+					acc = &campusAccumulator{
+						hours:    make(map[slotKey]float64),
+						sections: make(map[slotKey]map[string]struct{}),
+					}
+					campuses[campus] = acc
+				}
+
+				for _, day := range parseDays(schedule.Days) {
+					for slot := start / SlotMinutes; slot*SlotMinutes < end; slot++ {
+						slotStart := slot * SlotMinutes
+						overlap := min(end, slotStart+SlotMinutes) - max(start, slotStart)
+						if overlap <= 0 {
+							continue
+						}
+
+						key := slotKey{day: day, slot: slot}
+						acc.hours[key] += float64(overlap) / 60
+						if acc.sections[key] == nil {
+							acc.sections[key] = make(map[string]struct{})
+						}
+						acc.sections[key][sectionID] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	result := make([]model.CampusHeatmap, 0, len(campuses))
+	for campus, acc := range campuses {
+		result = append(result, acc.toHeatmap(campus))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Campus < result[j].Campus
+	})
+
+	return result
+}
+
+func (acc *campusAccumulator) toHeatmap(campus string) model.CampusHeatmap {
+	keys := make([]slotKey, 0, len(acc.hours))
+	for key := range acc.hours {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].day != keys[j].day {
+			return keys[i].day < keys[j].day
+		}
+		return keys[i].slot < keys[j].slot
+	})
+
+	heatmap := model.CampusHeatmap{
+		Campus: campus,
+		Slots:  make([]model.HeatmapSlot, 0, len(keys)),
+	}
+
+	total := 0.0
+	peak := -1
+	for _, key := range keys {
+		hours := acc.hours[key]
+		total += hours
+
+		heatmap.Slots = append(heatmap.Slots, model.HeatmapSlot{
+			Day:        weekdays[key.day],
+			StartTime:  formatClock(key.slot * SlotMinutes),
+			EndTime:    formatClock((key.slot + 1) * SlotMinutes),
+			ClassHours: round2(hours),
+			Sections:   len(acc.sections[key]),
+		})
+
+		if peak < 0 || hours > acc.hours[keys[peak]] {
+			peak = len(heatmap.Slots) - 1
+		}
+	}
+
+	if peak >= 0 {
+		peakSlot := heatmap.Slots[peak]
+		heatmap.PeakSlot = &peakSlot
+	}
+	heatmap.TotalClassHours = round2(total)
+
+	return heatmap
+}
+
+func matchesSchedule(schedule model.SectionSchedule, opts TimetableOptions) bool {
+	if opts.SectionCode != "" {
+		if !strings.EqualFold(schedule.SectionCode, opts.SectionCode) {
+			return false
+		}
+	} else if strings.EqualFold(schedule.SectionCode, examSectionCode) {
+		return false
+	}
+
+	if opts.Campus != "" {
+		campus := strings.TrimSpace(schedule.Campus)
+		if campus == "" {
+			campus = unknownCampus
+		}
+		if !strings.EqualFold(campus, opts.Campus) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseDays converts a days string such as "Mo, We, Fr" into weekday indexes.
+func parseDays(days string) []int {
+	var result []int
+	for _, part := range strings.Split(days, ",") {
+		part = strings.TrimSpace(part)
+		for i, day := range weekdays {
+			if strings.EqualFold(part, day) {
+				result = append(result, i)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// parseClock converts a time such as "8:30" or "14:20" into minutes after midnight.
+func parseClock(clock string) (int, bool) {
+	hourStr, minuteStr, found := strings.Cut(strings.TrimSpace(clock), ":")
+	if !found {
+		return 0, false
+	}
+
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil || hour < 0 || hour > 24 {
+		return 0, false
+	}
+	minute, err := strconv.Atoi(minuteStr)
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, false
+	}
+
+	return hour*60 + minute, true
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,85 @@
+package analytics
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+)
+
+func TestBuildTimetableHeatmap(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule model.SectionSchedule
+		opts     TimetableOptions
+		want     []string
+	}{
+		{
+			name:     "half-hour boundaries",
+			schedule: model.SectionSchedule{Campus: "Burnaby", Days: "Mo", StartTime: "10:30", EndTime: "11:20", SectionCode: "LEC"},
+			want:     []string{"Burnaby Mo 10:30-11:00 0.5", "Burnaby Mo 11:00-11:30 0.33"},
+		},
+		{
+			name:     "starts mid-slot",
+			schedule: model.SectionSchedule{Campus: "Burnaby", Days: "Tu", StartTime: "10:15", EndTime: "10:45", SectionCode: "LEC"},
+			want:     []string{"Burnaby Tu 10:00-10:30 0.25", "Burnaby Tu 10:30-11:00 0.25"},
+		},
+		{
+			name:     "multi-day",
+			schedule: model.SectionSchedule{Campus: "Surrey", Days: "Mo, We, Fr", StartTime: "8:30", EndTime: "9:20", SectionCode: "LEC"},
+			want: []string{
+				"Surrey Mo 08:30-09:00 0.5", "Surrey Mo 09:00-09:30 0.33",
+				"Surrey We 08:30-09:00 0.5", "Surrey We 09:00-09:30 0.33",
+				"Surrey Fr 08:30-09:00 0.5", "Surrey Fr 09:00-09:30 0.33",
+			},
+		},
+		{
+			name:     "exam skipped",
+			schedule: model.SectionSchedule{Campus: "Burnaby", Days: "Sa", StartTime: "12:00", EndTime: "15:00", SectionCode: "EXM"},
+			want:     nil,
+		},
+		{
+			name:     "exam requested",
+			schedule: model.SectionSchedule{Campus: "Burnaby", Days: "Sa", StartTime: "12:00", EndTime: "13:00", SectionCode: "EXM"},
+			opts:     TimetableOptions{SectionCode: "exm"},
+			want:     []string{"Burnaby Sa 12:00-12:30 0.5", "Burnaby Sa 12:30-13:00 0.5"},
+		},
+		{
+			name:     "no campus",
+			schedule: model.SectionSchedule{Days: "Th", StartTime: "14:30", EndTime: "15:00", SectionCode: "TUT"},
+			want:     []string{"Unknown Th 14:30-15:00 0.5"},
+		},
+		{
+			name:     "campus filtered out",
+			schedule: model.SectionSchedule{Campus: "Surrey", Days: "Mo", StartTime: "10:30", EndTime: "11:20", SectionCode: "LEC"},
+			opts:     TimetableOptions{Campus: "burnaby"},
+			want:     nil,
+		},
+		{
+			name:     "no meeting time",
+			schedule: model.SectionSchedule{Campus: "Burnaby", Days: "Mo", SectionCode: "LEC"},
+			want:     nil,
+		},
+	}
+
+	for _, tt := range tests {
+		courses := []model.CourseWithSectionDetails{{
+			Dept:   "CMPT",
+			Number: "225",
+			SectionDetails: []model.SectionDetail{
+				{Section: "D100", Schedules: []model.SectionSchedule{tt.schedule}},
+			},
+		}}
+
+		var got []string
+		for _, campus := range BuildTimetableHeatmap(courses, tt.opts) {
+			for _, slot := range campus.Slots {
+				got = append(got, fmt.Sprintf("%s %s %s-%s %v", campus.Campus, slot.Day, slot.StartTime, slot.EndTime, slot.ClassHours))
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: slots = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
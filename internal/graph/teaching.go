@@ -0,0 +1,319 @@
+package graph
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/brianrahadi/sfucourses-api/internal/identity"
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/brianrahadi/sfucourses-api/internal/utils"
+	"github.com/samber/lo"
+)
+
+// Node and edge types of a teaching graph.
+const (
+	InstructorNode = "instructor"
+	CourseNode     = "course"
+	TaughtEdge     = "taught"
+	CoTaughtEdge   = "co-taught"
+)
+
+type edgeKey struct {
+	source string
+	target string
+	typ    string
+}
+
+type edge struct {
+	terms   map[string]bool
+	courses map[string]bool
+}
+
+type teamTaught struct {
+	dept        string
+	number      string
+	terms       map[string]bool
+	instructors map[string]bool
+}
+
+// Teaching is a graph of instructors and courses built from section instructors.
+// Instructors are connected to the courses they taught, and to the instructors
+// they shared a section with. Edges are weighted by the number of terms.
+type Teaching struct {
+	nodes      map[string]model.GraphNode
+	edges      map[edgeKey]*edge
+	adjacency  map[string][]edgeKey
+	teamTaught map[string]*teamTaught
+}
+
+// InstructorNodeID returns the node ID of an instructor. Instructors without a
+// stable ID are keyed by their normalized name.
+// Example: InstructorNodeID("", "Dr. Jane Doe") returns "instructor:name:jane-doe"
+func InstructorNodeID(id, name string) string {
+	if id != "" {
+		return InstructorNode + ":" + id
+	}
+	return InstructorNode + ":name:" + identity.Slug(identity.NormalizeName(name))
+}
+
+// CourseNodeID returns the node ID of a course.
+// Example: CourseNodeID("cmpt", "225") returns "course:CMPT 225"
+func CourseNodeID(dept, number string) string {
+	return CourseNode + ":" + strings.ToUpper(dept) + " " + strings.ToUpper(number)
+}
+
+// BuildTeaching builds a teaching graph from the sections of every term, keyed
+// by term code.
+func BuildTeaching(sectionsByTerm map[string][]model.CourseWithSectionDetails) *Teaching {
+	g := &Teaching{
+		nodes:      make(map[string]model.GraphNode),
+		edges:      make(map[edgeKey]*edge),
+		adjacency:  make(map[string][]edgeKey),
+		teamTaught: make(map[string]*teamTaught),
+	}
+
+	// Terms are visited oldest first so that labels end up with the latest names
+	termCodes := lo.Keys(sectionsByTerm)
+	sort.Slice(termCodes, func(i, j int) bool {
+		return utils.TermSortKey(termCodes[i]) < utils.TermSortKey(termCodes[j])
+	})
+
+	for _, termCode := range termCodes {
+		term := utils.TermFromTermCode(termCode)
+
+		for _, course := range sectionsByTerm[termCode] {
+			courseID := CourseNodeID(course.Dept, course.Number)
+			courseCode := strings.TrimPrefix(courseID, CourseNode+":")
+			g.nodes[courseID] = model.GraphNode{ID: courseID, Type: CourseNode, Label: courseCode, Title: course.Title}
+
+			for _, section := range course.SectionDetails {
+				var instructorIDs []string
+				for _, instructor := range section.Instructors {
+					if identity.IsPlaceholder(instructor.Name) {
+						continue
+					}
+					instructorID := InstructorNodeID(instructor.ID, instructor.Name)
+					if lo.Contains(instructorIDs, instructorID) {
+						continue
+					}
+					instructorIDs = append(instructorIDs, instructorID)
+					g.nodes[instructorID] = model.GraphNode{ID: instructorID, Type: InstructorNode, Label: identity.CleanName(instructor.Name)}
+					g.addEdge(instructorID, courseID, TaughtEdge, term, "")
+				}
+
+				sort.Strings(instructorIDs)
+				for i := range instructorIDs {
+					for j := i + 1; j < len(instructorIDs); j++ {
+						g.addEdge(instructorIDs[i], instructorIDs[j], CoTaughtEdge, term, courseCode)
+					}
+				}
+
+				if len(instructorIDs) > 1 {
+					team := g.teamTaught[courseID]
+					if team == nil {
+						team = &teamTaught{
+							dept:        strings.ToUpper(course.Dept),
+							number:      strings.ToUpper(course.Number),
+							terms:       make(map[string]bool),
+							instructors: make(map[string]bool),
+						}
+						g.teamTaught[courseID] = team
+					}
+					team.terms[term] = true
+					for _, instructorID := range instructorIDs {
+						team.instructors[instructorID] = true
+					}
+				}
+			}
+		}
+	}
+
+	return g
+}
+
+func (g *Teaching) addEdge(source, target, typ, term, course string) {
+	key := edgeKey{source: source, target: target, typ: typ}
+	e := g.edges[key]
+	if e == nil {
+		e = &edge{terms: make(map[string]bool), courses: make(map[string]bool)}
+		g.edges[key] = e
+		g.adjacency[source] = append(g.adjacency[source], key)
+		g.adjacency[target] = append(g.adjacency[target], key)
+	}
+	e.terms[term] = true
+	if course != "" {
+		e.courses[course] = true
+	}
+}
+
+// HasNode reports whether the graph contains a node.
+func (g *Teaching) HasNode(id string) bool {
+	_, ok := g.nodes[id]
+	return ok
+}
+
+// All returns the whole graph, or only the courses of a department and the
+// instructors who taught them if dept is not empty.
+func (g *Teaching) All(dept string) model.TeachingGraph {
+	dept = strings.ToUpper(dept)
+
+	var keys []edgeKey
+	for key, e := range g.edges {
+		switch {
+		case dept == "":
+		case key.typ == TaughtEdge && !strings.HasPrefix(key.target, CourseNode+":"+dept+" "):
+			continue
+		case key.typ == CoTaughtEdge && !lo.SomeBy(lo.Keys(e.courses), func(course string) bool {
+			return strings.HasPrefix(course, dept+" ")
+		}):
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	return g.subgraph(keys, nil)
+}
+
+// InstructorNeighbors returns an instructor with the courses they taught and the
+// instructors they co-taught with. With a depth of 2 or more, the other
+// instructors of those courses are included as well.
+func (g *Teaching) InstructorNeighbors(instructorID string, depth int) model.TeachingGraph {
+	keys := append([]edgeKey{}, g.adjacency[instructorID]...)
+
+	if depth >= 2 {
+		for _, key := range g.adjacency[instructorID] {
+			if key.typ != TaughtEdge {
+				continue
+			}
+			for _, courseKey := range g.adjacency[key.target] {
+				if courseKey.source != instructorID {
+					keys = append(keys, courseKey)
+				}
+			}
+		}
+	}
+
+	return g.subgraph(keys, []string{instructorID})
+}
+
+// CourseNeighbors returns a course with every instructor who taught it, and the
+// co-taught edges between those instructors for that course.
+func (g *Teaching) CourseNeighbors(courseID string) model.TeachingGraph {
+	courseCode := strings.TrimPrefix(courseID, CourseNode+":")
+	keys := append([]edgeKey{}, g.adjacency[courseID]...)
+
+	for _, key := range g.adjacency[courseID] {
+		for _, instructorKey := range g.adjacency[key.source] {
+			if instructorKey.typ == CoTaughtEdge && g.edges[instructorKey].courses[courseCode] {
+				keys = append(keys, instructorKey)
+			}
+		}
+	}
+
+	return g.subgraph(keys, []string{courseID})
+}
+
+// TeamTaught returns the courses with at least one section taught by more than
+// one instructor, optionally limited to a department.
+func (g *Teaching) TeamTaught(dept string) []model.TeamTaughtCourse {
+	dept = strings.ToUpper(dept)
+
+	result := []model.TeamTaughtCourse{}
+	for courseID, team := range g.teamTaught {
+		if dept != "" && team.dept != dept {
+			continue
+		}
+
+		instructors := lo.Map(lo.Keys(team.instructors), func(id string, _ int) string {
+			return g.nodes[id].Label
+		})
+		sort.Strings(instructors)
+
+		result = append(result, model.TeamTaughtCourse{
+			Dept:        team.dept,
+			Number:      team.number,
+			Title:       g.nodes[courseID].Title,
+			Terms:       sortTerms(lo.Keys(team.terms)),
+			Instructors: instructors,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Dept != result[j].Dept {
+			return result[i].Dept < result[j].Dept
+		}
+		return result[i].Number < result[j].Number
+	})
+	return result
+}
+
+// subgraph returns the given edges along with their nodes and any extra nodes.
+func (g *Teaching) subgraph(keys []edgeKey, extraNodes []string) model.TeachingGraph {
+	result := model.TeachingGraph{
+		Nodes: []model.GraphNode{},
+		Edges: []model.GraphEdge{},
+	}
+
+	nodeIDs := make(map[string]bool)
+	for _, id := range extraNodes {
+		if g.HasNode(id) {
+			nodeIDs[id] = true
+		}
+	}
+
+	seen := make(map[edgeKey]bool)
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		nodeIDs[key.source] = true
+		nodeIDs[key.target] = true
+
+		e := g.edges[key]
+		courses := lo.Keys(e.courses)
+		sort.Strings(courses)
+		result.Edges = append(result.Edges, model.GraphEdge{
+			Source:  key.source,
+			Target:  key.target,
+			Type:    key.typ,
+			Weight:  len(e.terms),
+			Terms:   sortTerms(lo.Keys(e.terms)),
+			Courses: courses,
+		})
+	}
+
+	for id := range nodeIDs {
+		result.Nodes = append(result.Nodes, g.nodes[id])
+	}
+
+	sort.Slice(result.Nodes, func(i, j int) bool {
+		if result.Nodes[i].Type != result.Nodes[j].Type {
+			return result.Nodes[i].Type > result.Nodes[j].Type // instructors first
+		}
+		return result.Nodes[i].ID < result.Nodes[j].ID
+	})
+	sort.Slice(result.Edges, func(i, j int) bool {
+		ei, ej := result.Edges[i], result.Edges[j]
+		if ei.Weight != ej.Weight {
+			return ei.Weight > ej.Weight
+		}
+		if ei.Source != ej.Source {
+			return ei.Source < ej.Source
+		}
+		if ei.Target != ej.Target {
+			return ei.Target < ej.Target
+		}
+		return ei.Type < ej.Type
+	})
+
+	return result
+}
+
+// sortTerms sorts display terms oldest first.
+func sortTerms(terms []string) []string {
+	sort.Slice(terms, func(i, j int) bool {
+		return utils.TermSortKey(utils.TermCodeFromTerm(terms[i])) < utils.TermSortKey(utils.TermCodeFromTerm(terms[j]))
+	})
+	return terms
+}
@@ -0,0 +1,57 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+)
+
+func section(instructors ...model.Instructor) model.SectionDetail {
+	return model.SectionDetail{Instructors: instructors}
+}
+
+func TestBuildTeaching(t *testing.T) {
+	alice := model.Instructor{ID: "alice", Name: "Alice Smith"}
+	bob := model.Instructor{ID: "bob", Name: "Bob Jones"}
+	tba := model.Instructor{Name: "TBA"}
+
+	g := BuildTeaching(map[string][]model.CourseWithSectionDetails{
+		"2024-fall": {
+			{Dept: "CMPT", Number: "225", SectionDetails: []model.SectionDetail{section(alice, bob), section(alice)}},
+		},
+		"2025-spring": {
+			{Dept: "CMPT", Number: "225", SectionDetails: []model.SectionDetail{section(alice, tba)}},
+			{Dept: "MATH", Number: "100", SectionDetails: []model.SectionDetail{section(bob)}},
+		},
+	})
+
+	neighbors := g.InstructorNeighbors("instructor:alice", 1)
+	if len(neighbors.Nodes) != 3 {
+		t.Fatalf("expected alice, bob and CMPT 225, got %v", neighbors.Nodes)
+	}
+
+	for _, edge := range neighbors.Edges {
+		switch edge.Type {
+		case TaughtEdge:
+			if edge.Weight != 2 {
+				t.Errorf("taught edge weight = %d, want 2", edge.Weight)
+			}
+		case CoTaughtEdge:
+			if edge.Weight != 1 || len(edge.Courses) != 1 || edge.Courses[0] != "CMPT 225" {
+				t.Errorf("co-taught edge = %+v, want weight 1 for CMPT 225", edge)
+			}
+		}
+	}
+
+	if got := g.InstructorNeighbors("instructor:alice", 2); len(got.Nodes) != 3 {
+		t.Errorf("depth 2 nodes = %v, want no courses beyond CMPT 225", got.Nodes)
+	}
+	if got := g.InstructorNeighbors("instructor:bob", 1); len(got.Nodes) != 4 {
+		t.Errorf("bob's nodes = %v, want bob, alice, CMPT 225 and MATH 100", got.Nodes)
+	}
+
+	team := g.TeamTaught("")
+	if len(team) != 1 || team[0].Number != "225" || len(team[0].Terms) != 1 {
+		t.Errorf("TeamTaught() = %+v, want CMPT 225 in Fall 2024 only", team)
+	}
+}
@@ -0,0 +1,255 @@
+package identity
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/samber/lo"
+)
+
+var (
+	whitespacePattern = regexp.MustCompile(`\s+`)
+	titlePattern      = regexp.MustCompile(`(?i)^(dr|prof|professor)\.?\s+`)
+)
+
+// Observation is a single sighting of an instructor in the data, such as an
+// instructor entry of a section or a review file.
+type Observation struct {
+	Name        string
+	Email       string
+	Depts       []string // department codes of the courses the name was seen with
+	ReviewFile  string   // review file path relative to instructor_reviews, if any
+	ReviewCount int
+}
+
+// Builder assigns stable IDs to instructors by clustering observations on
+// email first and name second. Identities from a previous alias table keep
+// their IDs, so manual edits to the table are preserved across syncs.
+type Builder struct {
+	index
+	identities   map[string]*model.InstructorIdentity
+	seeded       map[string]bool
+	nameCounts   map[string]map[string]int
+	reviewCounts map[string]map[string]int
+	observations []Observation
+}
+
+// NewBuilder creates a builder seeded with the identities of an existing alias table.
+func NewBuilder(seed []model.InstructorIdentity) *Builder {
+	b := &Builder{
+		index:        newIndex(),
+		identities:   make(map[string]*model.InstructorIdentity, len(seed)),
+		seeded:       make(map[string]bool, len(seed)),
+		nameCounts:   make(map[string]map[string]int),
+		reviewCounts: make(map[string]map[string]int),
+	}
+
+	for _, identity := range seed {
+		if identity.ID == "" {
+			continue
+		}
+		seeded := identity
+		seeded.Aliases = append([]string{}, identity.Aliases...)
+		seeded.Emails = append([]string{}, identity.Emails...)
+		seeded.Depts = append([]string{}, identity.Depts...)
+		seeded.ReviewFiles = nil // review files are recomputed on every build
+		b.identities[seeded.ID] = &seeded
+		b.seeded[seeded.ID] = true
+
+		for _, email := range seeded.Emails {
+			b.addEmail(email, seeded.ID)
+		}
+		b.addName(seeded.Name, seeded.ID)
+		for _, alias := range seeded.Aliases {
+			b.addName(alias, seeded.ID)
+		}
+	}
+
+	return b
+}
+
+// Add records an observation. Observations are resolved when Build is called.
+func (b *Builder) Add(observation Observation) {
+	if IsPlaceholder(observation.Name) {
+		return
+	}
+	b.observations = append(b.observations, observation)
+}
+
+// Build resolves all observations and returns the identities sorted by ID.
+// Observations with an email are resolved first, then section names without
+// an email, then review files, so the strongest evidence decides each cluster.
+func (b *Builder) Build() []model.InstructorIdentity {
+	sort.SliceStable(b.observations, func(i, j int) bool {
+		oi, oj := b.observations[i], b.observations[j]
+		if ri, rj := observationRank(oi), observationRank(oj); ri != rj {
+			return ri < rj
+		}
+		if oi.Email != oj.Email {
+			return NormalizeEmail(oi.Email) < NormalizeEmail(oj.Email)
+		}
+		if oi.Name != oj.Name {
+			return oi.Name < oj.Name
+		}
+		return oi.ReviewFile < oj.ReviewFile
+	})
+
+	for _, observation := range b.observations {
+		id := b.match(observation)
+		if id == "" {
+			id = b.create(observation)
+		}
+		b.attach(id, observation)
+	}
+
+	result := make([]model.InstructorIdentity, 0, len(b.identities))
+	for _, identity := range b.identities {
+		sort.Strings(identity.Aliases)
+		sort.Strings(identity.Emails)
+		sort.Strings(identity.Depts)
+
+		// New identities are named after their most used name, seeded ones keep theirs
+		if !b.seeded[identity.ID] {
+			identity.Name = mostUsedName(b.nameCounts[identity.ID], identity.Name)
+		}
+
+		counts := b.reviewCounts[identity.ID]
+		identity.ReviewFiles = lo.Keys(counts)
+		sort.Slice(identity.ReviewFiles, func(i, j int) bool {
+			fi, fj := identity.ReviewFiles[i], identity.ReviewFiles[j]
+			if counts[fi] != counts[fj] {
+				return counts[fi] > counts[fj]
+			}
+			return fi < fj
+		})
+
+		result = append(result, *identity)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
+
+func observationRank(observation Observation) int {
+	switch {
+	case observation.Email != "":
+		return 0
+	case observation.ReviewFile == "":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// match returns the ID of an existing identity the observation belongs to,
+// or an empty string if it belongs to a new one.
+func (b *Builder) match(observation Observation) string {
+	email := NormalizeEmail(observation.Email)
+	if id, ok := b.byEmail[email]; ok {
+		return id
+	}
+
+	ids := b.candidates(observation.Name)
+	deptsOf := func(id string) []string { return b.identities[id].Depts }
+
+	if email != "" {
+		// A known name with a new email is the same person only if they teach
+		// in the same department, or if the name has never had an email before.
+		for _, id := range ids {
+			if len(b.identities[id].Emails) == 0 {
+				return id
+			}
+		}
+		return bestByDepts(ids, observation.Depts, deptsOf)
+	}
+
+	if len(ids) == 1 {
+		return ids[0]
+	}
+	return bestByDepts(ids, observation.Depts, deptsOf)
+}
+
+func (b *Builder) create(observation Observation) string {
+	base := EmailID(observation.Email)
+	if base == "" {
+		base = Slug(NormalizeName(observation.Name))
+	}
+
+	id := base
+	for i := 2; b.identities[id] != nil; i++ {
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	b.identities[id] = &model.InstructorIdentity{
+		ID:      id,
+		Name:    CleanName(observation.Name),
+		Aliases: []string{},
+	}
+	return id
+}
+
+func (b *Builder) attach(id string, observation Observation) {
+	identity := b.identities[id]
+
+	name := CleanName(observation.Name)
+	if !lo.Contains(identity.Aliases, name) {
+		identity.Aliases = append(identity.Aliases, name)
+	}
+	if b.nameCounts[id] == nil {
+		b.nameCounts[id] = make(map[string]int)
+	}
+	b.nameCounts[id][name]++
+	b.addName(observation.Name, id)
+
+	if email := NormalizeEmail(observation.Email); email != "" {
+		if !lo.Contains(identity.Emails, email) {
+			identity.Emails = append(identity.Emails, email)
+		}
+		b.addEmail(email, id)
+	}
+
+	if observation.ReviewFile != "" {
+		if b.reviewCounts[id] == nil {
+			b.reviewCounts[id] = make(map[string]int)
+		}
+		b.reviewCounts[id][observation.ReviewFile] = observation.ReviewCount
+		return
+	}
+
+	// Review course codes are user-entered, so only section departments are kept
+	for _, dept := range observation.Depts {
+		if !lo.Contains(identity.Depts, dept) {
+			identity.Depts = append(identity.Depts, dept)
+		}
+	}
+}
+
+// mostUsedName returns the name seen most often, preferring the alphabetically
+// first name on ties.
+func mostUsedName(counts map[string]int, fallback string) string {
+	best, bestCount := fallback, 0
+	for name, count := range counts {
+		if count > bestCount || (count == bestCount && name < best) {
+			best, bestCount = name, count
+		}
+	}
+	return best
+}
+
+// CleanName trims titles, parenthetical roles, ", role" suffixes and extra
+// whitespace from a name while keeping its original spelling.
+// Example: CleanName("Dr. Erin Barley (Lab Instructor)") returns "Erin Barley"
+func CleanName(name string) string {
+	name = parentheticalPattern.ReplaceAllString(name, " ")
+	if i := strings.Index(name, ","); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.TrimSpace(whitespacePattern.ReplaceAllString(name, " "))
+	return titlePattern.ReplaceAllString(name, "")
+}
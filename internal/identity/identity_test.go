@@ -0,0 +1,141 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"Isabelle Côté":                    "isabelle cote",
+		"Dr. Erin Barley (Lab Instructor)": "erin barley",
+		"Sean O'Flaherty":                  "sean oflaherty",
+		"Jane Doe, Course Coordinator":     "jane doe",
+		"  Prof.   Mary-Anne   Smith  ":    "mary anne smith",
+		"TBA":                              "tba",
+	}
+	for input, want := range tests {
+		if got := NormalizeName(input); got != want {
+			t.Errorf("NormalizeName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestCleanName(t *testing.T) {
+	if got := CleanName("Dr. Erin  Barley (Lab Instructor)"); got != "Erin Barley" {
+		t.Errorf("CleanName() = %q, want \"Erin Barley\"", got)
+	}
+}
+
+func TestNameKey(t *testing.T) {
+	if got := NameKey("sheena miao ying tan"); got != "sheena tan" {
+		t.Errorf("NameKey() = %q, want \"sheena tan\"", got)
+	}
+}
+
+func TestIsPlaceholder(t *testing.T) {
+	for _, name := range []string{"TBA", "Faculty", "Sessional Instructor", ""} {
+		if !IsPlaceholder(name) {
+			t.Errorf("IsPlaceholder(%q) = false, want true", name)
+		}
+	}
+	if IsPlaceholder("Bobby Chan") {
+		t.Errorf("IsPlaceholder(\"Bobby Chan\") = true, want false")
+	}
+}
+
+func TestBuildMergesNameWithEmail(t *testing.T) {
+	b := NewBuilder(nil)
+	b.Add(Observation{Name: "Bobby Chan", Email: "bchan@sfu.ca", Depts: []string{"CMPT"}})
+	b.Add(Observation{Name: "Bobby Chan", Depts: []string{"CMPT"}})
+	b.Add(Observation{Name: "Dr. Bobby Chan", ReviewFile: "CMPT/Bobby_Chan.json", ReviewCount: 3})
+
+	identities := b.Build()
+	if len(identities) != 1 {
+		t.Fatalf("expected 1 identity, got %d", len(identities))
+	}
+	got := identities[0]
+	if got.ID != "bchan" {
+		t.Errorf("ID = %q, want \"bchan\"", got.ID)
+	}
+	if len(got.ReviewFiles) != 1 || got.ReviewFiles[0] != "CMPT/Bobby_Chan.json" {
+		t.Errorf("ReviewFiles = %v, want [CMPT/Bobby_Chan.json]", got.ReviewFiles)
+	}
+}
+
+func TestBuildSeparatesNamesakes(t *testing.T) {
+	b := NewBuilder(nil)
+	b.Add(Observation{Name: "Isabelle Cote", Email: "imcote@sfu.ca", Depts: []string{"BISC"}})
+	b.Add(Observation{Name: "Isabelle Cote", Email: "icote@sfu.ca", Depts: []string{"EDUC"}})
+	b.Add(Observation{Name: "Isabelle Côté", Depts: []string{"BISC", "BISC"}, ReviewFile: "BISC/Isabelle_Cote.json", ReviewCount: 2})
+
+	identities := b.Build()
+	if len(identities) != 2 {
+		t.Fatalf("expected 2 identities, got %d", len(identities))
+	}
+
+	r := NewResolver(identities)
+	if got := r.ResolveInDepts("Isabelle Cote", []string{"EDUC"}); got != "icote" {
+		t.Errorf("ResolveInDepts(EDUC) = %q, want \"icote\"", got)
+	}
+	if got := r.Resolve("Isabelle Cote", ""); got != "" {
+		t.Errorf("Resolve() of an ambiguous name = %q, want \"\"", got)
+	}
+	if got := r.Resolve("", "IMCOTE@sfu.ca"); got != "imcote" {
+		t.Errorf("Resolve() by email = %q, want \"imcote\"", got)
+	}
+}
+
+func TestBuildKeepsSeededIDs(t *testing.T) {
+	seed := []model.InstructorIdentity{
+		{ID: "sandy-rutherford", Name: "Sandy Rutherford", Aliases: []string{"Sandy Rutherford"}},
+	}
+	b := NewBuilder(seed)
+	b.Add(Observation{Name: "Sandy Rutherford", Depts: []string{"MATH"}})
+
+	identities := b.Build()
+	if len(identities) != 1 || identities[0].ID != "sandy-rutherford" {
+		t.Fatalf("expected seeded identity to be kept, got %v", identities)
+	}
+}
+
+func TestNameIndexSearch(t *testing.T) {
+	idx := NewNameIndex()
+	idx.Add(0, "Jörg Müller")
+	idx.Add(1, "Alexander Rutherford")
+	idx.Add(1, "Sandy Rutherford")
+	idx.Add(2, "Linyi Li")
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"muller", 0},
+		{"MÜLLER jorg", 0},
+		{"rutherfrod", 1},
+		{"sandy", 1},
+		{"li", 2},
+	}
+	for _, tt := range tests {
+		matches := idx.Search(tt.query, 0)
+		if len(matches) == 0 || matches[0].Ref != tt.want {
+			t.Errorf("Search(%q) = %v, want first ref %d", tt.query, matches, tt.want)
+		}
+	}
+
+	if matches := idx.Search("lu", 0); len(matches) != 0 {
+		t.Errorf("Search(\"lu\") = %v, want no matches for a short typo", matches)
+	}
+}
+
+func TestNameIndexSearchRanksExactFirst(t *testing.T) {
+	idx := NewNameIndex()
+	idx.Add(0, "Anna Chen Smith")
+	idx.Add(1, "Anna Chen")
+
+	matches := idx.Search("anna chen", 0)
+	if len(matches) != 2 || matches[0].Ref != 1 {
+		t.Errorf("Search(\"anna chen\") = %v, want exact match first", matches)
+	}
+}
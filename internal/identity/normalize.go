@@ -0,0 +1,100 @@
+package identity
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	parentheticalPattern = regexp.MustCompile(`\([^)]*\)`)
+	honorifics           = map[string]bool{"dr": true, "prof": true, "professor": true, "mr": true, "mrs": true, "ms": true}
+)
+
+// RemoveAccents strips diacritics so that "Côté" and "Cote" compare equal.
+func RemoveAccents(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return result
+}
+
+// NormalizeName reduces an instructor name to a comparable form.
+// Parenthetical roles, trailing ", role" suffixes, honorifics, accents,
+// punctuation and case are removed.
+// Example: NormalizeName("Dr. Isabelle Côté (Lab Instructor)") returns "isabelle cote"
+func NormalizeName(name string) string {
+	name = parentheticalPattern.ReplaceAllString(name, " ")
+	if i := strings.Index(name, ","); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.ToLower(RemoveAccents(name))
+
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case r == '\'' || r == '’':
+			// O'Flaherty and OFlaherty are the same name
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	tokens := strings.Fields(b.String())
+	tokens = dropHonorifics(tokens)
+	return strings.Join(tokens, " ")
+}
+
+func dropHonorifics(tokens []string) []string {
+	for len(tokens) > 1 && honorifics[tokens[0]] {
+		tokens = tokens[1:]
+	}
+	return tokens
+}
+
+// NameKey returns the first and last token of a normalized name, so that
+// names with and without middle names share a key.
+// Example: NameKey("sheena miao ying tan") returns "sheena tan"
+func NameKey(normalized string) string {
+	tokens := strings.Fields(normalized)
+	if len(tokens) <= 2 {
+		return strings.Join(tokens, " ")
+	}
+	return tokens[0] + " " + tokens[len(tokens)-1]
+}
+
+// Slug converts a normalized name into an ID-friendly form.
+// Example: Slug("bobby chan") returns "bobby-chan"
+func Slug(normalized string) string {
+	return strings.ReplaceAll(normalized, " ", "-")
+}
+
+// IsPlaceholder reports whether a name is a placeholder rather than a person,
+// such as "TBA", "Faculty" or "Sessional".
+func IsPlaceholder(name string) bool {
+	normalized := NormalizeName(name)
+	return normalized == "" ||
+		strings.Contains(name, "TBA") ||
+		strings.Contains(normalized, "faculty") ||
+		strings.Contains(normalized, "sessional")
+}
+
+// EmailID returns the lowercase local part of an email address.
+// Example: EmailID("John_Doe@sfu.ca") returns "john_doe"
+func EmailID(email string) string {
+	local, _, _ := strings.Cut(NormalizeEmail(email), "@")
+	return local
+}
+
+// NormalizeEmail lowercases and trims an email address.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,163 @@
+package identity
+
+import (
+	"strings"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/samber/lo"
+)
+
+// index maps emails and names to instructor IDs.
+type index struct {
+	byEmail map[string]string
+	byAlias map[string][]string
+	byKey   map[string][]string
+}
+
+func newIndex() index {
+	return index{
+		byEmail: make(map[string]string),
+		byAlias: make(map[string][]string),
+		byKey:   make(map[string][]string),
+	}
+}
+
+func (idx index) addEmail(email, id string) {
+	email = NormalizeEmail(email)
+	if email == "" {
+		return
+	}
+	if _, exists := idx.byEmail[email]; !exists {
+		idx.byEmail[email] = id
+	}
+}
+
+func (idx index) addName(name, id string) {
+	normalized := NormalizeName(name)
+	if normalized == "" {
+		return
+	}
+	if !lo.Contains(idx.byAlias[normalized], id) {
+		idx.byAlias[normalized] = append(idx.byAlias[normalized], id)
+	}
+	key := NameKey(normalized)
+	if !lo.Contains(idx.byKey[key], id) {
+		idx.byKey[key] = append(idx.byKey[key], id)
+	}
+}
+
+// candidates returns the IDs whose aliases match a name, falling back to
+// matching on first and last name only.
+func (idx index) candidates(name string) []string {
+	normalized := NormalizeName(name)
+	if normalized == "" {
+		return nil
+	}
+	if ids := idx.byAlias[normalized]; len(ids) > 0 {
+		return ids
+	}
+	return idx.byKey[NameKey(normalized)]
+}
+
+// Resolver resolves names and emails to stable instructor IDs.
+type Resolver struct {
+	index
+	byID         map[string]model.InstructorIdentity
+	byReviewFile map[string]string
+}
+
+// NewResolver builds a resolver from the identities of an alias table.
+func NewResolver(identities []model.InstructorIdentity) *Resolver {
+	r := &Resolver{
+		index:        newIndex(),
+		byID:         make(map[string]model.InstructorIdentity, len(identities)),
+		byReviewFile: make(map[string]string),
+	}
+
+	for _, identity := range identities {
+		r.byID[identity.ID] = identity
+		for _, email := range identity.Emails {
+			r.addEmail(email, identity.ID)
+		}
+		r.addName(identity.Name, identity.ID)
+		for _, alias := range identity.Aliases {
+			r.addName(alias, identity.ID)
+		}
+		for _, file := range identity.ReviewFiles {
+			r.byReviewFile[file] = identity.ID
+		}
+	}
+
+	return r
+}
+
+// Resolve returns the ID of the instructor with the given email, or with the
+// given name if the name is unambiguous. Returns an empty string otherwise.
+func (r *Resolver) Resolve(name, email string) string {
+	if r == nil {
+		return ""
+	}
+	if id, ok := r.byEmail[NormalizeEmail(email)]; ok {
+		return id
+	}
+	if ids := r.candidates(name); len(ids) == 1 {
+		return ids[0]
+	}
+	return ""
+}
+
+// ResolveInDepts resolves a name like Resolve, using the department codes the
+// name was seen with to tell namesakes apart.
+func (r *Resolver) ResolveInDepts(name string, depts []string) string {
+	if r == nil {
+		return ""
+	}
+	ids := r.candidates(name)
+	if len(ids) == 1 {
+		return ids[0]
+	}
+	return bestByDepts(ids, depts, func(id string) []string {
+		return r.byID[id].Depts
+	})
+}
+
+// Get returns the identity with the given ID.
+func (r *Resolver) Get(id string) (model.InstructorIdentity, bool) {
+	if r == nil {
+		return model.InstructorIdentity{}, false
+	}
+	identity, ok := r.byID[strings.ToLower(strings.TrimSpace(id))]
+	return identity, ok
+}
+
+// Lookup returns the identity matching an ID, email or unambiguous name.
+func (r *Resolver) Lookup(query string) (model.InstructorIdentity, bool) {
+	if identity, ok := r.Get(query); ok {
+		return identity, true
+	}
+	return r.Get(r.Resolve(query, query))
+}
+
+// ReviewFileID returns the ID of the instructor a review file belongs to.
+func (r *Resolver) ReviewFileID(file string) string {
+	if r == nil {
+		return ""
+	}
+	return r.byReviewFile[file]
+}
+
+// bestByDepts returns the ID whose departments cover the most entries of depts.
+// Repeated entries in depts weigh more, so a review file with many CMPT reviews
+// and one MATH review goes to the CMPT instructor. Ties go to the earliest ID.
+// Returns an empty string when no ID overlaps at all.
+func bestByDepts(ids []string, depts []string, deptsOf func(string) []string) string {
+	best, bestScore := "", 0
+	for _, id := range ids {
+		idDepts := deptsOf(id)
+		score := lo.CountBy(depts, func(dept string) bool { return lo.Contains(idDepts, dept) })
+		if score > bestScore {
+			best, bestScore = id, score
+		}
+	}
+	return best
+}
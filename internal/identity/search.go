@@ -0,0 +1,201 @@
+package identity
+
+import (
+	"sort"
+	"strings"
+)
+
+// Token match scores. A query matches a name only if every query token matches
+// some token of the name, in any order.
+const (
+	exactTokenScore     = 1.0
+	prefixTokenScore    = 0.9
+	substringTokenScore = 0.7
+	typoTokenScore      = 0.8 // minus typoPenalty per edit
+	typoPenalty         = 0.15
+	fullNameBonus       = 0.5
+	namePrefixBonus     = 0.2
+)
+
+// SearchMatch is a name matching a search query.
+type SearchMatch struct {
+	Ref   int     // reference passed to NameIndex.Add
+	Name  string  // the indexed name that matched best
+	Score float64 // relevance, higher is better
+}
+
+type nameEntry struct {
+	ref        int
+	name       string
+	normalized string
+	tokens     []string
+}
+
+// NameIndex is a fuzzy, accent-insensitive index of instructor names.
+type NameIndex struct {
+	entries []nameEntry
+}
+
+// NewNameIndex creates an empty name index.
+func NewNameIndex() *NameIndex {
+	return &NameIndex{}
+}
+
+// Add indexes a name under a reference, usually a position in a slice.
+// A reference can be added with several names, such as a name and its aliases.
+func (idx *NameIndex) Add(ref int, name string) {
+	normalized := NormalizeName(name)
+	if normalized == "" {
+		return
+	}
+	idx.entries = append(idx.entries, nameEntry{
+		ref:        ref,
+		name:       name,
+		normalized: normalized,
+		tokens:     strings.Fields(normalized),
+	})
+}
+
+// Search returns the references matching a query, best match first.
+// Returns all matches if limit is zero or less.
+// Example: Search("muler", 0) matches "Jörg Müller" with one typo
+func (idx *NameIndex) Search(query string, limit int) []SearchMatch {
+	normalized := NormalizeName(query)
+	if normalized == "" {
+		return nil
+	}
+	queryTokens := strings.Fields(normalized)
+
+	best := make(map[int]SearchMatch)
+	for _, entry := range idx.entries {
+		score := scoreEntry(queryTokens, normalized, entry)
+		if score == 0 {
+			continue
+		}
+		if current, ok := best[entry.ref]; !ok || score > current.Score {
+			best[entry.ref] = SearchMatch{Ref: entry.ref, Name: entry.name, Score: score}
+		}
+	}
+
+	matches := make([]SearchMatch, 0, len(best))
+	for _, match := range best {
+		matches = append(matches, match)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if matches[i].Name != matches[j].Name {
+			return matches[i].Name < matches[j].Name
+		}
+		return matches[i].Ref < matches[j].Ref
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// scoreEntry returns the relevance of a name for a query, or 0 if it doesn't match.
+func scoreEntry(queryTokens []string, query string, entry nameEntry) float64 {
+	total := 0.0
+	for _, queryToken := range queryTokens {
+		tokenScore := 0.0
+		for _, token := range entry.tokens {
+			tokenScore = max(tokenScore, scoreToken(queryToken, token))
+		}
+		if tokenScore == 0 {
+			return 0
+		}
+		total += tokenScore
+	}
+
+	score := total / float64(len(queryTokens))
+	switch {
+	case entry.normalized == query:
+		score += fullNameBonus
+	case strings.HasPrefix(entry.normalized, query):
+		score += namePrefixBonus
+	}
+	return score
+}
+
+func scoreToken(queryToken, token string) float64 {
+	switch {
+	case queryToken == token:
+		return exactTokenScore
+	case strings.HasPrefix(token, queryToken):
+		return prefixTokenScore
+	case len(queryToken) >= 3 && strings.Contains(token, queryToken):
+		return substringTokenScore
+	}
+
+	maxEdits := allowedEdits(queryToken)
+	if maxEdits == 0 {
+		return 0
+	}
+	if d := editDistance(queryToken, token, maxEdits); d <= maxEdits {
+		return typoTokenScore - typoPenalty*float64(d-1)
+	}
+	return 0
+}
+
+// allowedEdits returns how many typos a query token may contain.
+// Short tokens must match exactly, or "li" would match half of all names.
+func allowedEdits(token string) int {
+	switch n := len([]rune(token)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance returns the Damerau-Levenshtein distance between a and b,
+// counting an adjacent transposition as one edit. Returns limit+1 as soon as
+// the distance is known to exceed limit.
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if abs(len(ra)-len(rb)) > limit {
+		return limit + 1
+	}
+
+	prevPrev := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prevPrev[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+
+	return prev[len(rb)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Registry holds the metrics exposed at /metrics, along with Go runtime and
+// process metrics.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+// HTTP metrics, labelled by route pattern such as "GET /v1/rest/outlines"
+var (
+	RequestsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "sfucourses_http_requests_total",
+		Help: "HTTP requests by route and status.",
+	}, []string{"route", "status"})
+
+	RequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sfucourses_http_request_duration_seconds",
+		Help:    "HTTP request latency by route and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "status"})
+
+	ResponseSize = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sfucourses_http_response_size_bytes",
+		Help:    "HTTP response size before compression by route.",
+		Buckets: prometheus.ExponentialBuckets(256, 4, 9), // 256B to 16MB
+	}, []string{"route"})
+
+	RequestsInFlight = factory.NewGauge(prometheus.GaugeOpts{
+		Name: "sfucourses_http_requests_in_flight",
+		Help: "HTTP requests being served.",
+	})
+)
+
+// Data metrics
+var (
+	StoreReloads = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "sfucourses_store_reloads_total",
+		Help: "Store reloads from the JSON data files by store and result.",
+	}, []string{"store", "result"})
+
+	StoreReloadDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sfucourses_store_reload_duration_seconds",
+		Help:    "Store reload duration by store.",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 12), // 10ms to 20s
+	}, []string{"store"})
+
+	SyncSteps = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "sfucourses_data_sync_steps_total",
+		Help: "Data sync steps run by step and result.",
+	}, []string{"step", "result"})
+
+	SyncStepDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sfucourses_data_sync_step_duration_seconds",
+		Help:    "Data sync step duration by step.",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 12), // 1s to 34m
+	}, []string{"step"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// RegisterDatasetAge exposes the age of the data served, given the time it
+// was last updated. It must be called once, as registering twice panics.
+func RegisterDatasetAge(lastUpdate func() time.Time) {
+	factory.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "sfucourses_dataset_age_seconds",
+		Help: "Time since the data was last synced.",
+	}, func() float64 {
+		return time.Since(lastUpdate()).Seconds()
+	})
+}
+
+// result labels the outcome of an operation.
+func result(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+// ObserveReload records a store reload.
+func ObserveReload(store string, duration time.Duration, err error) {
+	StoreReloads.WithLabelValues(store, result(err)).Inc()
+	StoreReloadDuration.WithLabelValues(store).Observe(duration.Seconds())
+}
+
+// ObserveSyncStep records a data sync step.
+func ObserveSyncStep(step string, duration time.Duration, err error) {
+	SyncSteps.WithLabelValues(step, result(err)).Inc()
+	SyncStepDuration.WithLabelValues(step).Observe(duration.Seconds())
+}
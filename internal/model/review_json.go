@@ -0,0 +1,55 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// flexString decodes a JSON string or number into a string. Review files from
+// RateMyProfessors store ratings and votes as strings, while the precomputed
+// course review files store them as numbers.
+type flexString string
+
+func (s *flexString) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		*s = flexString(str)
+		return nil
+	}
+
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+	*s = flexString(number.String())
+	return nil
+}
+
+// UnmarshalJSON decodes a review whose ratings and votes may be strings or numbers.
+func (r *Review) UnmarshalJSON(data []byte) error {
+	type review Review
+	var raw struct {
+		review
+		Rating     flexString `json:"rating"`
+		Difficulty flexString `json:"difficulty"`
+		Helpful    flexString `json:"helpful"`
+		NotHelpful flexString `json:"not_helpful"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*r = Review(raw.review)
+	r.Rating = string(raw.Rating)
+	r.Difficulty = string(raw.Difficulty)
+	r.Helpful = string(raw.Helpful)
+	r.NotHelpful = string(raw.NotHelpful)
+	return nil
+}
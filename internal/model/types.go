@@ -26,6 +26,14 @@ type ShortCourseOutline struct {
 	Units  string `json:"units" example:"3" description:"Number of credit units"`
 }
 
+// CourseSearchResult represents a course outline matching a search query
+// @Description Course outline matching a search query, with its relevance score
+type CourseSearchResult struct {
+	ShortCourseOutline
+	Score         float64  `json:"score" example:"12.48" description:"Relevance score; higher is more relevant"`
+	MatchedFields []string `json:"matchedFields" example:"title,description" description:"Fields matching the query: code, title, designation, description or notes"`
+}
+
 // CourseWithSectionDetails represents a course with its section details
 // @Description Course with detailed section information
 type CourseWithSectionDetails struct {
@@ -73,17 +81,44 @@ type SectionDetail struct {
 // Instructor represents an instructor's information
 // @Description Instructor information
 type Instructor struct {
-	Name  string `json:"name" example:"John Doe" description:"Instructor's full name"`
-	Email string `json:"email" example:"john_doe@sfu.ca" description:"Instructor's email address"`
+	ID      string             `json:"id,omitempty" example:"jdoe" description:"Stable instructor ID"`
+	Name    string             `json:"name" example:"John Doe" description:"Instructor's full name"`
+	Email   string             `json:"email" example:"john_doe@sfu.ca" description:"Instructor's email address"`
+	Ratings *InstructorRatings `json:"ratings,omitempty" description:"Review summary, if ratings were requested and the instructor has reviews"`
+}
+
+// InstructorRatings represents the review summary of a section instructor
+// @Description Instructor review summary from RateMyProfessors; values missing from RateMyProfessors are null
+type InstructorRatings struct {
+	AvgRating           *float64 `json:"avgRating" example:"4.2" description:"Overall rating from 1 to 5"`
+	AvgDifficulty       *float64 `json:"avgDifficulty" example:"3.5" description:"Overall difficulty from 1 to 5"`
+	WouldTakeAgain      *float64 `json:"wouldTakeAgain" example:"85" description:"Percentage who would take again, from 0 to 100"`
+	ReviewCount         int      `json:"reviewCount" example:"13" description:"Total number of ratings"`
+	CourseAvgRating     *float64 `json:"courseAvgRating,omitempty" example:"4.5" description:"Average rating in reviews of this course, if any"`
+	CourseAvgDifficulty *float64 `json:"courseAvgDifficulty,omitempty" example:"3" description:"Average difficulty in reviews of this course, if any"`
+	CourseReviewCount   int      `json:"courseReviewCount" example:"4" description:"Number of reviews of this course"`
 }
 
 // InstructorResponse represents instructor information
 // @Description Instructor information
 type InstructorResponse struct {
+	ID        string               `json:"id,omitempty" example:"jdoe" description:"Stable instructor ID"`
 	Name      string               `json:"name" example:"John Doe" description:"Instructor's full name"`
+	Aliases   []string             `json:"aliases,omitempty" example:"John Doe,John A. Doe" description:"Other names this instructor appears under"`
 	Offerings []InstructorOffering `json:"offerings" description:"List of course offerings"`
 }
 
+// InstructorSearchResult represents an instructor matching a search query
+// @Description Instructor search result, ranked by relevance
+type InstructorSearchResult struct {
+	ID          string               `json:"id,omitempty" example:"jdoe" description:"Stable instructor ID"`
+	Name        string               `json:"name" example:"John Doe" description:"Instructor's full name"`
+	Aliases     []string             `json:"aliases,omitempty" example:"John Doe,John A. Doe" description:"Other names this instructor appears under"`
+	MatchedName string               `json:"matchedName" example:"John A. Doe" description:"The name or alias that matched the query"`
+	Score       float64              `json:"score" example:"1.5" description:"Relevance score, higher is better"`
+	Offerings   []InstructorOffering `json:"offerings" description:"List of course offerings"`
+}
+
 // InstructorOffering represents an instructor's offering of a course
 // @Description Instructor offering information
 type InstructorOffering struct {
@@ -126,14 +161,18 @@ type SectionInfo struct {
 	ClassNumber    string `json:"classNumber" example:"6327" description:"Class number for registration"`
 }
 
-// AllCourseOutlinesResponse represents a paginated response of course outlines
-// @Description Paginated response containing course outlines
-type AllCourseOutlinesResponse struct {
-	Data       []CourseOutline `json:"data" description:"List of course outlines"`
-	TotalCount int             `json:"total_count" example:"3412" description:"Total count of outlines matching the query"`
-	NextURL    string          `json:"next_url,omitempty" example:"/v1/rest/outlines?limit=100&offset=50" description:"URL for the next page of results"`
+// PaginatedResponse represents one page of a list
+// @Description Paginated response with the total count and links to the adjacent pages
+type PaginatedResponse[T any] struct {
+	Data       []T    `json:"data" description:"Items of the page"`
+	TotalCount int    `json:"total_count" example:"3412" description:"Total count of items matching the query"`
+	NextURL    string `json:"next_url,omitempty" example:"/v1/rest/outlines?limit=100&offset=200" description:"URL for the next page of results"`
+	PrevURL    string `json:"prev_url,omitempty" example:"/v1/rest/outlines?limit=100&offset=0" description:"URL for the previous page of results"`
 }
 
+// AllCourseOutlinesResponse represents a paginated response of course outlines
+type AllCourseOutlinesResponse = PaginatedResponse[CourseOutline]
+
 // ReviewMetadata represents metadata for a review
 // @Description Review metadata information
 type ReviewMetadata struct {
@@ -148,54 +187,87 @@ type ReviewMetadata struct {
 // Review represents a detailed review
 // @Description Detailed review information
 type Review struct {
-	Rating     string         `json:"rating" example:"4.0" description:"Review rating"`
-	Difficulty string         `json:"difficulty" example:"3.0" description:"Difficulty rating"`
-	CourseCode string         `json:"course_code" example:"BUS251" description:"Course code"`
-	Date       string         `json:"date" example:"Sep 1st, 2020" description:"Review date"`
-	Metadata   ReviewMetadata `json:"metadata" description:"Review metadata"`
-	ReviewMsg  string         `json:"review_msg" example:"Great professor!" description:"Review message"`
-	Helpful    string         `json:"helpful" example:"5" description:"Number of helpful votes"`
-	NotHelpful string         `json:"not_helpful" example:"1" description:"Number of not helpful votes"`
-	Tags       []string       `json:"tags" example:"clear grading criteria,participation matters" description:"Review tags"`
+	Rating        string         `json:"rating" example:"4.0" description:"Review rating"`
+	Difficulty    string         `json:"difficulty" example:"3.0" description:"Difficulty rating"`
+	CourseCode    string         `json:"course_code" example:"BUS251" description:"Course code, resolved against the outline catalog when possible"`
+	CourseCodeRaw string         `json:"course_code_raw,omitempty" example:"bus-251" description:"Course code as entered by the reviewer, if it was resolved to a different code"`
+	Date          string         `json:"date" example:"Sep 1st, 2020" description:"Review date as displayed on RateMyProfessors"`
+	DateISO       string         `json:"date_iso,omitempty" example:"2020-09-01" description:"Review date in YYYY-MM-DD format, if it could be parsed"`
+	Metadata      ReviewMetadata `json:"metadata" description:"Review metadata"`
+	ReviewMsg     string         `json:"review_msg" example:"Great professor!" description:"Review message"`
+	Helpful       string         `json:"helpful" example:"5" description:"Number of helpful votes"`
+	NotHelpful    string         `json:"not_helpful" example:"1" description:"Number of not helpful votes"`
+	Tags          []string       `json:"tags" example:"clear grading criteria,participation matters" description:"Review tags"`
 }
 
 // InstructorReviewData represents instructor-level review data
 // @Description Instructor review data from RateMyProfessors
 type InstructorReviewData struct {
-	ProfessorID     string   `json:"professor_id" example:"2326813" description:"RateMyProfessors professor ID"`
-	ProfessorName   string   `json:"professor_name" example:"Angela Lin" description:"Professor's name"`
-	OverallRating   string   `json:"overall_rating" example:"4.2" description:"Overall rating"`
-	WouldTakeAgain  string   `json:"would_take_again" example:"85" description:"Percentage who would take again"`
-	DifficultyLevel string   `json:"difficulty_level" example:"3.5" description:"Difficulty level"`
-	Department      string   `json:"department" example:"Accounting department" description:"Department name"`
-	TotalRatings    string   `json:"total_ratings" example:"13" description:"Total number of ratings"`
-	Reviews         []Review `json:"reviews" description:"List of detailed reviews"`
+	InstructorID    string      `json:"instructor_id,omitempty" example:"alin" description:"Stable instructor ID"`
+	ProfessorID     string      `json:"professor_id" example:"2326813" description:"RateMyProfessors professor ID"`
+	ProfessorName   string      `json:"professor_name" example:"Angela Lin" description:"Professor's name"`
+	OverallRating   string      `json:"overall_rating" example:"4.2" description:"Overall rating"`
+	WouldTakeAgain  string      `json:"would_take_again" example:"85" description:"Percentage who would take again"`
+	DifficultyLevel string      `json:"difficulty_level" example:"3.5" description:"Difficulty level"`
+	Department      string      `json:"department" example:"Accounting department" description:"Department name"`
+	TotalRatings    string      `json:"total_ratings" example:"13" description:"Total number of ratings"`
+	Keywords        []Keyword   `json:"keywords,omitempty" description:"Most distinctive phrases of the reviews"`
+	Reviews         []Review    `json:"reviews" description:"List of detailed reviews"`
+	Page            *ReviewPage `json:"page,omitempty" description:"Pagination of the reviews, if filtering, sorting or pagination was requested"`
+}
+
+// Keyword represents a distinctive phrase of a set of reviews
+// @Description Phrase scored by TF-IDF against all reviews
+type Keyword struct {
+	Phrase string  `json:"phrase" example:"curved exams" description:"Word or two-word phrase, lowercased"`
+	Count  int     `json:"count" example:"12" description:"Number of reviews mentioning the phrase"`
+	Score  float64 `json:"score" example:"0.0842" description:"TF-IDF score; higher is more distinctive"`
+}
+
+// ReviewKeywords represents the keywords extracted from reviews by extract-keywords
+type ReviewKeywords struct {
+	Instructors map[string][]Keyword `json:"instructors"` // keyed by instructor review file path
+	Courses     map[string][]Keyword `json:"courses"`     // keyed by course code
 }
 
 // InstructorSummary represents a summary of instructor reviews
 // @Description Summary information for an instructor
 type InstructorSummary struct {
-	ProfessorID    string   `json:"professor_id" example:"2326813" description:"RateMyProfessors professor ID"`
-	ProfessorName  string   `json:"professor_name" example:"Angela Lin" description:"Professor's name"`
-	Department     string   `json:"department" example:"Accounting" description:"Department name"`
-	AvgRating      float64  `json:"avg_rating" example:"4.2" description:"Average rating"`
-	AvgDifficulty  float64  `json:"avg_difficulty" example:"3.5" description:"Average difficulty"`
-	ReviewCount    int      `json:"review_count" example:"13" description:"Number of reviews"`
-	WouldTakeAgain string   `json:"would_take_again" example:"85%" description:"Percentage who would take again"`
-	Reviews        []Review `json:"reviews" description:"List of detailed reviews"`
+	InstructorID   string      `json:"instructor_id,omitempty" example:"alin" description:"Stable instructor ID"`
+	ProfessorID    string      `json:"professor_id" example:"2326813" description:"RateMyProfessors professor ID"`
+	ProfessorName  string      `json:"professor_name" example:"Angela Lin" description:"Professor's name"`
+	Department     string      `json:"department" example:"Accounting" description:"Department name"`
+	AvgRating      float64     `json:"avg_rating" example:"4.2" description:"Average rating"`
+	AvgDifficulty  float64     `json:"avg_difficulty" example:"3.5" description:"Average difficulty"`
+	ReviewCount    int         `json:"review_count" example:"13" description:"Number of reviews"`
+	WouldTakeAgain string      `json:"would_take_again" example:"85%" description:"Percentage who would take again"`
+	Reviews        []Review    `json:"reviews" description:"List of detailed reviews"`
+	Page           *ReviewPage `json:"page,omitempty" description:"Pagination of the reviews, if filtering, sorting or pagination was requested"`
+}
+
+// ReviewPage represents the position of a page of reviews among all matching reviews
+// @Description Pagination of a list of reviews
+type ReviewPage struct {
+	Total  int `json:"total" example:"42" description:"Number of reviews matching the filters"`
+	Limit  int `json:"limit,omitempty" example:"20" description:"Maximum number of reviews returned"`
+	Offset int `json:"offset" example:"0" description:"Number of matching reviews skipped"`
 }
 
 // CourseReviewData represents course-level review data
 // @Description Course review data aggregated from instructors
 type CourseReviewData struct {
-	CourseCode   string              `json:"course_code" example:"BUS251" description:"Course code"`
-	TotalReviews int                 `json:"total_reviews" example:"25" description:"Total number of reviews"`
-	Instructors  []InstructorSummary `json:"instructors" description:"List of instructors who taught this course"`
+	CourseCode    string              `json:"course_code" example:"BUS251" description:"Course code"`
+	TotalReviews  int                 `json:"total_reviews" example:"25" description:"Total number of reviews"`
+	AvgRating     float64             `json:"avg_rating" example:"3.8" description:"Average rating, weighted by the review count of each instructor"`
+	AvgDifficulty float64             `json:"avg_difficulty" example:"3.1" description:"Average difficulty, weighted by the review count of each instructor"`
+	Keywords      []Keyword           `json:"keywords,omitempty" description:"Most distinctive phrases of the reviews"`
+	Instructors   []InstructorSummary `json:"instructors" description:"List of instructors who taught this course"`
 }
 
 // ProfessorSummary represents summary review data for a professor
 // @Description Summary review information for a professor
 type ProfessorSummary struct {
+	InstructorID   string `json:"instructor_id,omitempty" example:"cfung" description:"Stable instructor ID"`
 	URL            string `json:"URL" example:"https://www.ratemyprofessors.com/professor/2865715" description:"RateMyProfessors URL"`
 	Quality        string `json:"Quality" example:"3.1" description:"Overall quality rating"`
 	Ratings        string `json:"Ratings" example:"14" description:"Total number of ratings"`
@@ -205,6 +277,15 @@ type ProfessorSummary struct {
 	Department     string `json:"Department" example:"Gender Studies" description:"Department name"`
 }
 
+// QuarantinedCourseCode represents a review course code that couldn't be resolved to a catalog course
+// @Description Review course code left out of course reviews
+type QuarantinedCourseCode struct {
+	CourseCode  string   `json:"course_code" example:"PHILXX1" description:"Course code as entered by reviewers, without spaces or punctuation"`
+	Reason      string   `json:"reason" example:"malformed" description:"Why the code couldn't be resolved: malformed, no department, unknown department or not in catalog"`
+	ReviewCount int      `json:"review_count" example:"132" description:"Number of reviews with this code"`
+	Instructors []string `json:"instructors" example:"Angela Lin" description:"Names of the reviewed instructors"`
+}
+
 // CourseSummary represents summary review data for a course
 // @Description Summary review information for a course
 type CourseSummary struct {
@@ -214,6 +295,80 @@ type CourseSummary struct {
 	AvgDifficulty float64 `json:"avg_difficulty" example:"2.0" description:"Average difficulty rating"`
 }
 
+// ReviewV2 represents a detailed review with typed values
+// @Description Detailed review information; values missing from RateMyProfessors are null
+type ReviewV2 struct {
+	Rating              *float64 `json:"rating" example:"4" description:"Review rating from 1 to 5"`
+	Difficulty          *float64 `json:"difficulty" example:"3" description:"Difficulty rating from 1 to 5"`
+	CourseCode          string   `json:"course_code" example:"BUS251" description:"Course code as entered by the reviewer"`
+	Date                *string  `json:"date" example:"2020-09-01" description:"Review date in YYYY-MM-DD format"`
+	ForCredit           *bool    `json:"for_credit" example:"true" description:"Whether the course was taken for credit"`
+	AttendanceMandatory *bool    `json:"attendance_mandatory" example:"true" description:"Whether attendance was mandatory"`
+	WouldTakeAgain      *bool    `json:"would_take_again" example:"true" description:"Whether the reviewer would take the class again"`
+	Textbook            *bool    `json:"textbook" example:"true" description:"Whether a textbook was used"`
+	OnlineClass         bool     `json:"online_class" example:"false" description:"Whether the class was online"`
+	Grade               *string  `json:"grade" example:"B+" description:"Self-reported grade"`
+	ReviewMsg           string   `json:"review_msg" example:"Great professor!" description:"Review message"`
+	Helpful             int      `json:"helpful" example:"5" description:"Number of helpful votes"`
+	NotHelpful          int      `json:"not_helpful" example:"1" description:"Number of not helpful votes"`
+	Tags                []string `json:"tags" example:"Caring,Tough grader" description:"Review tags"`
+}
+
+// InstructorReviewDataV2 represents instructor-level review data with typed values
+// @Description Instructor review data from RateMyProfessors; values missing from RateMyProfessors are null
+type InstructorReviewDataV2 struct {
+	InstructorID    string      `json:"instructor_id,omitempty" example:"alin" description:"Stable instructor ID"`
+	ProfessorID     string      `json:"professor_id" example:"2326813" description:"RateMyProfessors professor ID"`
+	ProfessorName   string      `json:"professor_name" example:"Angela Lin" description:"Professor's name"`
+	OverallRating   *float64    `json:"overall_rating" example:"4.2" description:"Overall rating from 1 to 5"`
+	WouldTakeAgain  *float64    `json:"would_take_again" example:"85" description:"Percentage who would take again, from 0 to 100"`
+	DifficultyLevel *float64    `json:"difficulty_level" example:"3.5" description:"Difficulty level from 1 to 5"`
+	Department      string      `json:"department" example:"Accounting department" description:"Department name"`
+	TotalRatings    *int        `json:"total_ratings" example:"13" description:"Total number of ratings"`
+	Keywords        []Keyword   `json:"keywords,omitempty" description:"Most distinctive phrases of the reviews"`
+	Reviews         []ReviewV2  `json:"reviews" description:"List of detailed reviews"`
+	Page            *ReviewPage `json:"page,omitempty" description:"Pagination of the reviews, if filtering, sorting or pagination was requested"`
+}
+
+// InstructorSummaryV2 represents a summary of an instructor's reviews of a course with typed values
+// @Description Summary information for an instructor; values missing from RateMyProfessors are null
+type InstructorSummaryV2 struct {
+	InstructorID   string      `json:"instructor_id,omitempty" example:"alin" description:"Stable instructor ID"`
+	ProfessorID    string      `json:"professor_id" example:"2326813" description:"RateMyProfessors professor ID"`
+	ProfessorName  string      `json:"professor_name" example:"Angela Lin" description:"Professor's name"`
+	Department     string      `json:"department" example:"Accounting" description:"Department name"`
+	AvgRating      *float64    `json:"avg_rating" example:"4.2" description:"Average rating, null without ratings"`
+	AvgDifficulty  *float64    `json:"avg_difficulty" example:"3.5" description:"Average difficulty, null without ratings"`
+	ReviewCount    int         `json:"review_count" example:"13" description:"Number of reviews"`
+	WouldTakeAgain *float64    `json:"would_take_again" example:"85" description:"Percentage who would take again, from 0 to 100"`
+	Reviews        []ReviewV2  `json:"reviews" description:"List of detailed reviews"`
+	Page           *ReviewPage `json:"page,omitempty" description:"Pagination of the reviews, if filtering, sorting or pagination was requested"`
+}
+
+// CourseReviewDataV2 represents course-level review data with typed values
+// @Description Course review data aggregated from instructors
+type CourseReviewDataV2 struct {
+	CourseCode    string                `json:"course_code" example:"BUS251" description:"Course code"`
+	TotalReviews  int                   `json:"total_reviews" example:"25" description:"Total number of reviews"`
+	AvgRating     *float64              `json:"avg_rating" example:"3.8" description:"Average rating, weighted by the review count of each instructor"`
+	AvgDifficulty *float64              `json:"avg_difficulty" example:"3.1" description:"Average difficulty, weighted by the review count of each instructor"`
+	Keywords      []Keyword             `json:"keywords,omitempty" description:"Most distinctive phrases of the reviews"`
+	Instructors   []InstructorSummaryV2 `json:"instructors" description:"List of instructors who taught this course"`
+}
+
+// ProfessorSummaryV2 represents summary review data for a professor with typed values
+// @Description Summary review information for a professor; values missing from RateMyProfessors are null
+type ProfessorSummaryV2 struct {
+	InstructorID   string   `json:"instructor_id,omitempty" example:"cfung" description:"Stable instructor ID"`
+	URL            string   `json:"url" example:"https://www.ratemyprofessors.com/professor/2865715" description:"RateMyProfessors URL"`
+	Name           string   `json:"name" example:"Carman Fung" description:"Professor's name"`
+	Department     string   `json:"department" example:"Gender Studies" description:"Department name"`
+	Quality        *float64 `json:"quality" example:"3.1" description:"Overall quality rating from 1 to 5"`
+	Ratings        int      `json:"ratings" example:"14" description:"Total number of ratings"`
+	WouldTakeAgain *float64 `json:"would_take_again" example:"50" description:"Percentage who would take again, from 0 to 100"`
+	Difficulty     *float64 `json:"difficulty" example:"3.6" description:"Difficulty rating from 1 to 5"`
+}
+
 // PrereqNode represents a node in a prerequisite expression tree.
 // Type "course": a single course or special token (ID field populated).
 // Type "and"/"or": logical operator node (Children field populated).
@@ -231,3 +386,235 @@ type CourseOutlineWithPrereqs struct {
 	CourseOutline
 	ParsedPrerequisites *PrereqNode `json:"parsedPrerequisites,omitempty"`
 }
+
+// TimetableHeatmap represents weekly scheduled class-hours for a term, grouped by campus
+// @Description Weekly class-hours per weekday and time slot for a term, grouped by campus
+type TimetableHeatmap struct {
+	Term        string          `json:"term" example:"2025-fall" description:"Year and term of the heatmap"`
+	Dept        string          `json:"dept,omitempty" example:"CMPT" description:"Department filter, if any"`
+	SectionCode string          `json:"sectionCode,omitempty" example:"LEC" description:"Section type filter, if any"`
+	SlotMinutes int             `json:"slotMinutes" example:"30" description:"Length of each time slot in minutes"`
+	Campuses    []CampusHeatmap `json:"campuses" description:"Heatmap for each campus"`
+}
+
+// CampusHeatmap represents the scheduled class-hours of a single campus
+// @Description Scheduled class-hours of a single campus by weekday and time slot
+type CampusHeatmap struct {
+	Campus          string        `json:"campus" example:"Burnaby" description:"Campus location"`
+	TotalClassHours float64       `json:"totalClassHours" example:"5123.5" description:"Total weekly scheduled class-hours"`
+	PeakSlot        *HeatmapSlot  `json:"peakSlot,omitempty" description:"Time slot with the most scheduled class-hours"`
+	Slots           []HeatmapSlot `json:"slots" description:"Non-empty time slots ordered by weekday and start time"`
+}
+
+// HeatmapSlot represents the scheduled class-hours within one weekday time slot
+// @Description Scheduled class-hours within one weekday time slot
+type HeatmapSlot struct {
+	Day        string  `json:"day" example:"Mo" description:"Day of the week"`
+	StartTime  string  `json:"startTime" example:"10:30" description:"Start time of the slot"`
+	EndTime    string  `json:"endTime" example:"11:00" description:"End time of the slot"`
+	ClassHours float64 `json:"classHours" example:"42.5" description:"Weekly class-hours scheduled within the slot"`
+	Sections   int     `json:"sections" example:"85" description:"Number of sections meeting during the slot"`
+}
+
+// InstructorProfile represents everything known about an instructor in one response
+// @Description Instructor profile joining teaching history, current sections, contact and review summary
+type InstructorProfile struct {
+	ID              string                   `json:"id,omitempty" example:"jdoe" description:"Stable instructor ID"`
+	Name            string                   `json:"name" example:"John Doe" description:"Instructor's full name"`
+	Aliases         []string                 `json:"aliases,omitempty" example:"John Doe,John A. Doe" description:"Other names this instructor appears under"`
+	Email           string                   `json:"email,omitempty" example:"john_doe@sfu.ca" description:"Instructor's most recently listed email address"`
+	CurrentTerm     string                   `json:"currentTerm" example:"Fall 2024" description:"Term used for the current sections"`
+	CurrentSections []InstructorSection      `json:"currentSections" description:"Sections taught in the current term"`
+	TeachingHistory []InstructorTermHistory  `json:"teachingHistory" description:"Courses taught, grouped by term (most recent first)"`
+	Reviews         *InstructorReviewSummary `json:"reviews,omitempty" description:"Review summary, if reviews exist"`
+}
+
+// InstructorSection represents a section taught by an instructor
+// @Description Section taught by an instructor, including its schedules
+type InstructorSection struct {
+	Dept           string            `json:"dept" example:"CMPT" description:"Department code"`
+	Number         string            `json:"number" example:"225" description:"Course number"`
+	Title          string            `json:"title" example:"Data Structures and Algorithms" description:"Course title"`
+	Term           string            `json:"term" example:"Fall 2024" description:"Academic term"`
+	Section        string            `json:"section" example:"D100" description:"Section code"`
+	DeliveryMethod string            `json:"deliveryMethod" example:"In Person" description:"Method of section delivery"`
+	ClassNumber    string            `json:"classNumber" example:"6327" description:"Class number for registration"`
+	Schedules      []SectionSchedule `json:"schedules" description:"List of section schedules"`
+}
+
+// InstructorTermHistory represents the courses an instructor taught in a term
+// @Description Courses taught by an instructor in a single term
+type InstructorTermHistory struct {
+	Term    string               `json:"term" example:"Fall 2024" description:"Academic term"`
+	Courses []InstructorOffering `json:"courses" description:"Courses taught in the term"`
+}
+
+// InstructorStats represents teaching statistics of an instructor
+// @Description Teaching statistics of an instructor across all known terms
+type InstructorStats struct {
+	ID              string                  `json:"id,omitempty" example:"jdoe" description:"Stable instructor ID"`
+	Name            string                  `json:"name" example:"John Doe" description:"Instructor's full name"`
+	FirstTerm       string                  `json:"firstTerm" example:"Spring 2024" description:"First term taught"`
+	LastTerm        string                  `json:"lastTerm" example:"Fall 2025" description:"Most recent term taught"`
+	TermsTaught     int                     `json:"termsTaught" example:"5" description:"Number of terms with at least one course"`
+	TotalOfferings  int                     `json:"totalOfferings" example:"9" description:"Number of course offerings across all terms"`
+	TotalSections   int                     `json:"totalSections" example:"14" description:"Number of sections taught across all terms"`
+	LectureSections int                     `json:"lectureSections" example:"9" description:"Number of lecture (LEC) sections"`
+	LabSections     int                     `json:"labSections" example:"3" description:"Number of lab (LAB) sections"`
+	SectionTypes    map[string]int          `json:"sectionTypes" description:"Number of sections by section code (LEC, LAB, TUT, SEM, ...)"`
+	Departments     []InstructorDeptStats   `json:"departments" description:"Departments taught in, most offerings first"`
+	Courses         []InstructorCourseStats `json:"courses" description:"Distinct courses taught, most offerings first"`
+	Terms           []InstructorTermStats   `json:"terms" description:"Courses and sections taught per term, oldest first"`
+}
+
+// InstructorDeptStats represents how much an instructor taught in a department
+// @Description Teaching statistics of an instructor in a department
+type InstructorDeptStats struct {
+	Dept      string `json:"dept" example:"CMPT" description:"Department code"`
+	Courses   int    `json:"courses" example:"3" description:"Number of distinct courses taught"`
+	Offerings int    `json:"offerings" example:"6" description:"Number of course offerings"`
+	Sections  int    `json:"sections" example:"10" description:"Number of sections taught"`
+}
+
+// InstructorCourseStats represents how often an instructor taught a course
+// @Description Teaching statistics of an instructor for a course
+type InstructorCourseStats struct {
+	Dept      string `json:"dept" example:"CMPT" description:"Department code"`
+	Number    string `json:"number" example:"225" description:"Course number"`
+	Title     string `json:"title" example:"Data Structures and Programming" description:"Course title"`
+	Offerings int    `json:"offerings" example:"3" description:"Number of terms the course was taught"`
+	Sections  int    `json:"sections" example:"5" description:"Number of sections taught"`
+	FirstTerm string `json:"firstTerm" example:"Spring 2024" description:"First term the course was taught"`
+	LastTerm  string `json:"lastTerm" example:"Fall 2025" description:"Most recent term the course was taught"`
+}
+
+// InstructorTermStats represents what an instructor taught in a term
+// @Description Courses and sections taught by an instructor in a single term
+type InstructorTermStats struct {
+	Term            string   `json:"term" example:"Fall 2024" description:"Academic term"`
+	Courses         []string `json:"courses" example:"CMPT 225,CMPT 307" description:"Courses taught in the term"`
+	Sections        int      `json:"sections" example:"3" description:"Number of sections taught"`
+	LectureSections int      `json:"lectureSections" example:"2" description:"Number of lecture (LEC) sections"`
+	LabSections     int      `json:"labSections" example:"1" description:"Number of lab (LAB) sections"`
+}
+
+// InstructorReviewSummary represents instructor-level review data without the individual reviews
+// @Description Instructor review summary from RateMyProfessors
+type InstructorReviewSummary struct {
+	ProfessorID     string `json:"professor_id" example:"2326813" description:"RateMyProfessors professor ID"`
+	OverallRating   string `json:"overall_rating" example:"4.2" description:"Overall rating"`
+	WouldTakeAgain  string `json:"would_take_again" example:"85" description:"Percentage who would take again"`
+	DifficultyLevel string `json:"difficulty_level" example:"3.5" description:"Difficulty level"`
+	Department      string `json:"department" example:"Accounting department" description:"Department name"`
+	TotalRatings    string `json:"total_ratings" example:"13" description:"Total number of ratings"`
+}
+
+// InstructorIdentity represents a single person across sections, instructors and reviews
+// @Description Resolved instructor identity with its known names, emails and review files
+type InstructorIdentity struct {
+	ID          string   `json:"id" example:"jdoe" description:"Stable instructor ID"`
+	Name        string   `json:"name" example:"John Doe" description:"Canonical instructor name"`
+	Aliases     []string `json:"aliases" example:"John Doe,John A. Doe" description:"Names this instructor appears under"`
+	Emails      []string `json:"emails,omitempty" example:"jdoe@sfu.ca" description:"Email addresses this instructor appears under"`
+	Depts       []string `json:"depts,omitempty" example:"CMPT" description:"Department codes of courses taught"`
+	ReviewFiles []string `json:"reviewFiles,omitempty" example:"Computer Science/John_Doe.json" description:"Review files under instructor_reviews, most reviewed first"`
+}
+
+// TeachingGraph represents instructors and courses connected by who taught what
+// @Description Graph of instructors and courses, with "taught" and "co-taught" edges weighted by number of terms
+type TeachingGraph struct {
+	Nodes []GraphNode `json:"nodes" description:"Instructor and course nodes"`
+	Edges []GraphEdge `json:"edges" description:"Taught and co-taught edges"`
+}
+
+// GraphNode represents an instructor or a course in a teaching graph
+// @Description Instructor or course node
+type GraphNode struct {
+	ID    string `json:"id" example:"instructor:jdoe" description:"Node ID, prefixed with its type"`
+	Type  string `json:"type" example:"instructor" description:"Node type: instructor or course"`
+	Label string `json:"label" example:"John Doe" description:"Instructor name or course code"`
+	Title string `json:"title,omitempty" example:"Data Structures and Programming" description:"Course title (for type=course)"`
+}
+
+// GraphEdge represents a relationship between two nodes in a teaching graph
+// @Description Taught edge from an instructor to a course, or co-taught edge between two instructors
+type GraphEdge struct {
+	Source  string   `json:"source" example:"instructor:jdoe" description:"Source node ID"`
+	Target  string   `json:"target" example:"course:CMPT 225" description:"Target node ID"`
+	Type    string   `json:"type" example:"taught" description:"Edge type: taught or co-taught"`
+	Weight  int      `json:"weight" example:"3" description:"Number of terms the relationship was seen in"`
+	Terms   []string `json:"terms" example:"Fall 2024,Spring 2025" description:"Terms the relationship was seen in, oldest first"`
+	Courses []string `json:"courses,omitempty" example:"CMPT 225" description:"Courses taught together (for type=co-taught)"`
+}
+
+// TeamTaughtCourse represents a course with sections taught by more than one instructor
+// @Description Course with at least one section taught by several instructors
+type TeamTaughtCourse struct {
+	Dept        string   `json:"dept" example:"CMPT" description:"Department code"`
+	Number      string   `json:"number" example:"225" description:"Course number"`
+	Title       string   `json:"title" example:"Data Structures and Programming" description:"Course title"`
+	Terms       []string `json:"terms" example:"Fall 2024" description:"Terms with a team-taught section, oldest first"`
+	Instructors []string `json:"instructors" example:"John Doe,Jane Smith" description:"Instructors who co-taught a section of the course"`
+}
+
+// ReviewStats represents distributions computed from individual reviews
+// @Description Rating, difficulty, grade and tag distributions of a set of reviews
+type ReviewStats struct {
+	InstructorID            string            `json:"instructor_id,omitempty" example:"alin" description:"Stable instructor ID (for instructor stats)"`
+	ProfessorName           string            `json:"professor_name,omitempty" example:"Angela Lin" description:"Professor's name (for instructor stats)"`
+	CourseCode              string            `json:"course_code,omitempty" example:"CMPT225" description:"Course code (for course stats)"`
+	TotalReviews            int               `json:"total_reviews" example:"42" description:"Number of reviews the stats are computed from"`
+	AvgRating               float64           `json:"avg_rating" example:"4.2" description:"Average rating"`
+	AvgDifficulty           float64           `json:"avg_difficulty" example:"3.1" description:"Average difficulty"`
+	RatingHistogram         []HistogramBucket `json:"rating_histogram" description:"Number of reviews per rating, in steps of 0.5"`
+	DifficultyHistogram     []HistogramBucket `json:"difficulty_histogram" description:"Number of reviews per difficulty"`
+	GradeDistribution       []LabelCount      `json:"grade_distribution" description:"Self-reported grades, best grade first"`
+	TopTags                 []LabelCount      `json:"top_tags" description:"Most frequent tags"`
+	TextbookRate            RateStat          `json:"textbook_rate" description:"Share of reviews saying a textbook was used"`
+	AttendanceMandatoryRate RateStat          `json:"attendance_mandatory_rate" description:"Share of reviews saying attendance was mandatory"`
+	WouldTakeAgainRate      RateStat          `json:"would_take_again_rate" description:"Share of reviews saying they would take the class again"`
+}
+
+// HistogramBucket represents the number of reviews with a given value
+// @Description Histogram bucket
+type HistogramBucket struct {
+	Value float64 `json:"value" example:"4.5" description:"Bucket value"`
+	Count int     `json:"count" example:"12" description:"Number of reviews with this value"`
+}
+
+// LabelCount represents how often a label appears among reviews
+// @Description Label frequency
+type LabelCount struct {
+	Label   string  `json:"label" example:"A-" description:"Grade or tag"`
+	Count   int     `json:"count" example:"8" description:"Number of reviews with this label"`
+	Percent float64 `json:"percent" example:"19.05" description:"Percentage of the reviews that answered"`
+}
+
+// RateStat represents the share of yes answers to a review question
+// @Description Share of yes answers among the reviews that answered
+type RateStat struct {
+	Rate     float64 `json:"rate" example:"0.62" description:"Share of yes answers, from 0 to 1"`
+	Answered int     `json:"answered" example:"30" description:"Number of reviews that answered"`
+}
+
+// ReviewTrend represents how an instructor's reviews changed over time
+// @Description Average and rolling average rating and difficulty per term or year
+type ReviewTrend struct {
+	InstructorID  string       `json:"instructor_id,omitempty" example:"alin" description:"Stable instructor ID"`
+	ProfessorName string       `json:"professor_name" example:"Angela Lin" description:"Professor's name"`
+	CourseCode    string       `json:"course_code,omitempty" example:"CMPT225" description:"Course code the reviews are restricted to"`
+	Period        string       `json:"period" example:"term" description:"Period length, term or year"`
+	Window        int          `json:"window" example:"3" description:"Number of periods in each rolling average"`
+	Points        []TrendPoint `json:"points" description:"Periods from the earliest to the latest review, including periods without reviews"`
+}
+
+// TrendPoint represents the reviews of one term or year
+// @Description Reviews of one period
+type TrendPoint struct {
+	Period            string   `json:"period" example:"2024-fall" description:"Term code or year"`
+	Reviews           int      `json:"reviews" example:"4" description:"Number of reviews in this period"`
+	AvgRating         *float64 `json:"avg_rating" example:"4.25" description:"Average rating in this period, null without reviews"`
+	AvgDifficulty     *float64 `json:"avg_difficulty" example:"3" description:"Average difficulty in this period, null without reviews"`
+	RollingRating     *float64 `json:"rolling_rating" example:"4.1" description:"Average rating over the rolling window ending at this period"`
+	RollingDifficulty *float64 `json:"rolling_difficulty" example:"3.2" description:"Average difficulty over the rolling window ending at this period"`
+}
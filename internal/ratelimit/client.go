@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ParsePrefixes parses comma-separated IP addresses and CIDR prefixes, such as
+// "10.0.0.0/8,127.0.0.1", skipping invalid entries.
+func ParsePrefixes(list string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(entry); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+		}
+	}
+	return prefixes
+}
+
+// ClientIP returns the address of the client of a request. Behind trusted
+// proxies, it's the nearest address in X-Forwarded-For that isn't a trusted
+// proxy, since clients can prepend anything to the header.
+func ClientIP(r *http.Request, trustedProxies []netip.Prefix) netip.Addr {
+	addrPort, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		return netip.Addr{}
+	}
+	ip := addrPort.Addr().Unmap()
+
+	trusted := func(addr netip.Addr) bool {
+		for _, prefix := range trustedProxies {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+	if !trusted(ip) {
+		return ip
+	}
+
+	forwarded := strings.Join(r.Header.Values("X-Forwarded-For"), ",")
+	if forwarded == "" {
+		if realIP, err := netip.ParseAddr(strings.TrimSpace(r.Header.Get("X-Real-IP"))); err == nil {
+			return realIP.Unmap()
+		}
+		return ip
+	}
+
+	hops := strings.Split(forwarded, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		ip = hop.Unmap()
+		if !trusted(ip) {
+			break
+		}
+	}
+	return ip
+}
+
+// ClientKey returns the rate limit key of a client address. IPv6 clients are
+// keyed by their /64 network, as they usually get a whole one to pick from.
+func ClientKey(ip netip.Addr) string {
+	if ip.Is6() {
+		prefix, _ := ip.Prefix(64)
+		return prefix.String()
+	}
+	return ip.String()
+}
@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestAllow(t *testing.T) {
+	now := time.Date(2024, 9, 3, 10, 0, 0, 0, time.UTC)
+	l := NewLimiter()
+	l.now = func() time.Time { return now }
+	limit := Limit{Requests: 3, Period: 3 * time.Second}
+
+	for i := 0; i < 3; i++ {
+		if got := l.Allow("a", limit); !got.Allowed || got.Remaining != 2-i {
+			t.Errorf("Allow #%d = %+v, want allowed with %d remaining", i+1, got, 2-i)
+		}
+	}
+
+	got := l.Allow("a", limit)
+	if got.Allowed || got.RetryAfter != time.Second || got.Reset != 3*time.Second {
+		t.Errorf("Allow over limit = %+v, want denied, retry after 1s, reset in 3s", got)
+	}
+	if got := l.Allow("b", limit); !got.Allowed {
+		t.Errorf("Allow(b) = %+v, want allowed, as buckets are per key", got)
+	}
+
+	now = now.Add(1500 * time.Millisecond)
+	if got := l.Allow("a", limit); !got.Allowed || got.Remaining != 0 {
+		t.Errorf("Allow after 1.5s = %+v, want allowed with 0 remaining", got)
+	}
+
+	if got := l.Allow("a", Limit{}); !got.Allowed {
+		t.Errorf("Allow(zero limit) = %+v, want allowed", got)
+	}
+
+	now = now.Add(time.Minute)
+	l.Allow("b", limit)
+	if _, found := l.buckets["a"]; found {
+		t.Errorf("bucket of idle key a wasn't swept")
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	trusted := ParsePrefixes("10.0.0.0/8, 127.0.0.1, bogus")
+	if len(trusted) != 2 {
+		t.Fatalf("ParsePrefixes = %v, want 2 prefixes", trusted)
+	}
+
+	tests := []struct {
+		remoteAddr string
+		forwarded  string
+		want       string
+	}{
+		{"203.0.113.7:5000", "198.51.100.1", "203.0.113.7"},
+		{"10.1.2.3:5000", "", "10.1.2.3"},
+		{"10.1.2.3:5000", "198.51.100.1", "198.51.100.1"},
+		{"10.1.2.3:5000", "1.1.1.1, 198.51.100.1, 10.9.9.9", "198.51.100.1"},
+		{"127.0.0.1:5000", "not-an-ip, 198.51.100.1", "198.51.100.1"},
+		{"[::ffff:10.1.2.3]:5000", "198.51.100.1", "198.51.100.1"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = tt.remoteAddr
+		if tt.forwarded != "" {
+			r.Header.Set("X-Forwarded-For", tt.forwarded)
+		}
+		if got := ClientIP(r, trusted); got.String() != tt.want {
+			t.Errorf("ClientIP(%s, X-Forwarded-For: %s) = %s, want %s", tt.remoteAddr, tt.forwarded, got, tt.want)
+		}
+	}
+
+	if got := ClientKey(netip.MustParseAddr("2001:db8::1")); got != "2001:db8::/64" {
+		t.Errorf("ClientKey(2001:db8::1) = %s, want 2001:db8::/64", got)
+	}
+}
@@ -0,0 +1,180 @@
+package reviews
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/samber/lo"
+)
+
+// AggregateCourse merges the review files of one course. Instructor entries are
+// merged by instructor ID, or by name for unresolved instructors, which also
+// merges duplicate RateMyProfessors profiles of one instructor. Reviews that
+// appear more than once are kept once, and review counts and averages are
+// recomputed from the remaining reviews. Course averages are weighted by the
+// review count of each instructor.
+func AggregateCourse(courseCode string, files []model.CourseReviewData) model.CourseReviewData {
+	type merged struct {
+		summary model.InstructorSummary
+		seen    map[string]bool
+		// weighted sums of the file averages, for entries without reviews
+		ratingSum, difficultySum float64
+		weight                   int
+		mostReviews              int
+	}
+
+	course := model.CourseReviewData{CourseCode: courseCode}
+	byKey := make(map[string]*merged)
+	var order []string
+
+	for _, file := range files {
+		if course.CourseCode == "" {
+			course.CourseCode = file.CourseCode
+		}
+
+		for _, instructor := range file.Instructors {
+			key := instructor.InstructorID
+			if key == "" {
+				key = "name:" + strings.ToLower(strings.TrimSpace(instructor.ProfessorName))
+			}
+
+			m, exists := byKey[key]
+			if !exists {
+				m = &merged{summary: instructor, seen: make(map[string]bool), mostReviews: -1}
+				m.summary.Reviews = nil
+				byKey[key] = m
+				order = append(order, key)
+			}
+
+			// The profile with the most reviews names the merged entry
+			if instructor.ReviewCount > m.mostReviews {
+				m.mostReviews = instructor.ReviewCount
+				m.summary.ProfessorID = instructor.ProfessorID
+				m.summary.ProfessorName = instructor.ProfessorName
+				if instructor.WouldTakeAgain != "" {
+					m.summary.WouldTakeAgain = instructor.WouldTakeAgain
+				}
+			}
+			if m.summary.Department == "" {
+				m.summary.Department = instructor.Department
+			}
+
+			m.ratingSum += instructor.AvgRating * float64(instructor.ReviewCount)
+			m.difficultySum += instructor.AvgDifficulty * float64(instructor.ReviewCount)
+			m.weight += instructor.ReviewCount
+
+			for _, review := range instructor.Reviews {
+				reviewKey := dedupeKey(review)
+				if m.seen[reviewKey] {
+					continue
+				}
+				m.seen[reviewKey] = true
+				m.summary.Reviews = append(m.summary.Reviews, review)
+			}
+		}
+	}
+
+	var courseRatingSum, courseDifficultySum float64
+	var ratingWeight, difficultyWeight int
+
+	for _, key := range order {
+		m := byKey[key]
+		instructor := m.summary
+
+		if len(instructor.Reviews) > 0 {
+			ratings, difficulties := scores(instructor.Reviews)
+			instructor.ReviewCount = len(instructor.Reviews)
+			instructor.AvgRating = average(ratings)
+			instructor.AvgDifficulty = average(difficulties)
+			courseRatingSum += lo.Sum(ratings)
+			courseDifficultySum += lo.Sum(difficulties)
+			ratingWeight += len(ratings)
+			difficultyWeight += len(difficulties)
+		} else {
+			instructor.ReviewCount = m.weight
+			if m.weight > 0 {
+				instructor.AvgRating = round2(m.ratingSum / float64(m.weight))
+				instructor.AvgDifficulty = round2(m.difficultySum / float64(m.weight))
+			}
+			courseRatingSum += m.ratingSum
+			courseDifficultySum += m.difficultySum
+			ratingWeight += m.weight
+			difficultyWeight += m.weight
+		}
+
+		course.TotalReviews += instructor.ReviewCount
+		course.Instructors = append(course.Instructors, instructor)
+	}
+
+	if ratingWeight > 0 {
+		course.AvgRating = round2(courseRatingSum / float64(ratingWeight))
+	}
+	if difficultyWeight > 0 {
+		course.AvgDifficulty = round2(courseDifficultySum / float64(difficultyWeight))
+	}
+
+	return course
+}
+
+// AggregateInstructor merges the review files of one instructor identity, such
+// as duplicate RateMyProfessors profiles, into the first, which should be the
+// most reviewed. Reviews that appear more than once are kept once, and the
+// overall ratings are recomputed from the remaining reviews.
+func AggregateInstructor(files []model.InstructorReviewData) model.InstructorReviewData {
+	if len(files) == 0 {
+		return model.InstructorReviewData{}
+	}
+	instructor := files[0]
+	if len(files) == 1 {
+		return instructor
+	}
+
+	seen := make(map[string]bool)
+	instructor.Reviews = nil
+	for _, file := range files {
+		for _, review := range file.Reviews {
+			reviewKey := dedupeKey(review)
+			if seen[reviewKey] {
+				continue
+			}
+			seen[reviewKey] = true
+			instructor.Reviews = append(instructor.Reviews, review)
+		}
+	}
+	if len(instructor.Reviews) == 0 {
+		return files[0]
+	}
+
+	ratings, difficulties := scores(instructor.Reviews)
+	instructor.OverallRating = scoreLabel(ratings)
+	instructor.DifficultyLevel = scoreLabel(difficulties)
+	instructor.WouldTakeAgain = wouldTakeAgainPercent(instructor.Reviews)
+	instructor.TotalRatings = fmt.Sprint(len(instructor.Reviews))
+	return instructor
+}
+
+// dedupeKey identifies a review by its content, since reviews have no ID.
+func dedupeKey(review model.Review) string {
+	return strings.Join([]string{
+		review.Date,
+		NormalizeCourseCode(review.CourseCode),
+		review.Rating,
+		review.Difficulty,
+		review.Metadata.Grade,
+		strings.TrimSpace(review.ReviewMsg),
+	}, "\x00")
+}
+
+// scores returns the ratings and difficulties of the rated reviews.
+func scores(reviews []model.Review) (ratings, difficulties []float64) {
+	for _, review := range reviews {
+		if rating, ok := parseScore(review.Rating); ok {
+			ratings = append(ratings, rating)
+		}
+		if difficulty, ok := parseScore(review.Difficulty); ok {
+			difficulties = append(difficulties, difficulty)
+		}
+	}
+	return ratings, difficulties
+}
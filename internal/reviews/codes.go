@@ -0,0 +1,155 @@
+package reviews
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+)
+
+// Reasons a review course code can't be resolved.
+const (
+	ReasonMalformed    = "malformed"
+	ReasonNoDept       = "no department"
+	ReasonUnknownDept  = "unknown department"
+	ReasonNotInCatalog = "not in catalog"
+)
+
+// deptAliases maps department codes reviewers still use to current ones.
+var deptAliases = map[string]string{
+	"BIO":  "BISC",
+	"CS":   "CMPT",
+	"ENG":  "ENGL",
+	"FNST": "INDG",
+	"FPA":  "CA",
+	"KIN":  "BPK",
+	"PHY":  "PHYS",
+	"PSY":  "PSYC",
+	"WS":   "GSWS",
+}
+
+var (
+	nonAlphanumeric   = regexp.MustCompile(`[^A-Z0-9]`)
+	leadingLetters    = regexp.MustCompile(`^[A-Z]+`)
+	reviewCodePattern = regexp.MustCompile(`^([A-Z]*)(\d{3})([A-Z]*)$`)
+)
+
+// Catalog resolves user-entered review course codes to course codes of the
+// outline catalog, such as "CMPT225".
+type Catalog struct {
+	numbers map[string]map[string]bool // dept to course numbers
+}
+
+// NewCatalog builds a catalog from course outlines.
+func NewCatalog(outlines []model.CourseOutline) *Catalog {
+	c := &Catalog{numbers: make(map[string]map[string]bool)}
+	for _, outline := range outlines {
+		dept, number := strings.ToUpper(outline.Dept), strings.ToUpper(outline.Number)
+		if c.numbers[dept] == nil {
+			c.numbers[dept] = make(map[string]bool)
+		}
+		c.numbers[dept][number] = true
+	}
+	return c
+}
+
+// Resolve returns the catalog course code of a review course code, or the
+// reason it can't be resolved. Codes without a department, such as "225", are
+// looked up in depts, the instructor's departments from most to least
+// frequent; the first department offering the number wins. Lab and writing
+// suffixes are added or dropped to find a course, so "CMPT225L" resolves to
+// "CMPT225" and "BUS360" to "BUS360W".
+func (c *Catalog) Resolve(code string, depts []string) (string, string) {
+	match := reviewCodePattern.FindStringSubmatch(nonAlphanumeric.ReplaceAllString(strings.ToUpper(code), ""))
+	if match == nil {
+		return "", ReasonMalformed
+	}
+	dept, number, suffix := match[1], match[2], match[3]
+
+	if dept == "" {
+		if len(depts) == 0 {
+			return "", ReasonNoDept
+		}
+		for _, hint := range depts {
+			if resolved, found := c.findNumber(hint, number, suffix); found {
+				return hint + resolved, ""
+			}
+		}
+		return "", ReasonNotInCatalog
+	}
+
+	if alias, found := deptAliases[dept]; found && c.numbers[dept] == nil {
+		dept = alias
+	}
+	if c.numbers[dept] == nil {
+		return "", ReasonUnknownDept
+	}
+
+	resolved, found := c.findNumber(dept, number, suffix)
+	if !found {
+		return "", ReasonNotInCatalog
+	}
+	return dept + resolved, ""
+}
+
+// findNumber finds a course number with the suffix as entered, without it, or
+// with a W suffix for writing-intensive courses.
+func (c *Catalog) findNumber(dept, number, suffix string) (string, bool) {
+	numbers := c.numbers[dept]
+	for _, candidate := range []string{number + suffix, number, number + "W"} {
+		if numbers[candidate] {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// Dept returns the department of a resolved course code.
+// Example: Dept("CMPT225") returns "CMPT"
+func Dept(code string) string {
+	return leadingLetters.FindString(code)
+}
+
+// DeptHints returns the departments of the review course codes that name one,
+// most frequent first, for resolving codes without a department.
+func (c *Catalog) DeptHints(reviews []model.Review) []string {
+	counts := make(map[string]int)
+	for _, review := range reviews {
+		code, reason := c.Resolve(review.CourseCode, nil)
+		if reason == "" {
+			counts[Dept(code)]++
+		}
+	}
+
+	depts := make([]string, 0, len(counts))
+	for dept := range counts {
+		depts = append(depts, dept)
+	}
+	sort.Slice(depts, func(i, j int) bool {
+		if counts[depts[i]] != counts[depts[j]] {
+			return counts[depts[i]] > counts[depts[j]]
+		}
+		return depts[i] < depts[j]
+	})
+	return depts
+}
+
+// ResolveCourseCodes resolves the course code of every review, keeping the code
+// as entered in CourseCodeRaw when it changes. Returns the reason of every
+// review whose code couldn't be resolved, by index.
+func (c *Catalog) ResolveCourseCodes(reviews []model.Review, depts []string) map[int]string {
+	unresolved := make(map[int]string)
+	for i, review := range reviews {
+		code, reason := c.Resolve(review.CourseCode, depts)
+		if reason != "" {
+			unresolved[i] = reason
+			continue
+		}
+		if code != review.CourseCode {
+			reviews[i].CourseCodeRaw = review.CourseCode
+			reviews[i].CourseCode = code
+		}
+	}
+	return unresolved
+}
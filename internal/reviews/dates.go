@@ -0,0 +1,44 @@
+package reviews
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+)
+
+// DateLayout is the ISO 8601 layout used for normalized review dates.
+const DateLayout = "2006-01-02"
+
+var ordinalSuffixPattern = regexp.MustCompile(`(\d)(st|nd|rd|th)\b`)
+
+// ParseDate parses a RateMyProfessors display date.
+// Example: ParseDate("Sep 1st, 2020") returns 2020-09-01
+func ParseDate(date string) (time.Time, bool) {
+	date = ordinalSuffixPattern.ReplaceAllString(strings.TrimSpace(date), "$1")
+	parsed, err := time.Parse("Jan 2, 2006", date)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return parsed, true
+}
+
+// NormalizeDates sets the ISO date of every review whose display date parses.
+func NormalizeDates(reviews []model.Review) {
+	for i, review := range reviews {
+		if date, ok := ParseDate(review.Date); ok {
+			reviews[i].DateISO = date.Format(DateLayout)
+		}
+	}
+}
+
+// reviewDate returns the date of a review, preferring its ISO date.
+func reviewDate(review model.Review) (time.Time, bool) {
+	if review.DateISO != "" {
+		if date, err := time.Parse(DateLayout, review.DateISO); err == nil {
+			return date, true
+		}
+	}
+	return ParseDate(review.Date)
+}
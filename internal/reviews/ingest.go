@@ -0,0 +1,387 @@
+package reviews
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/samber/lo"
+)
+
+// ExportColumns are the CSV export columns, one row per review. Instructor
+// columns are repeated on every row of the instructor; overall_rating,
+// would_take_again, difficulty_level and total_ratings are computed from the
+// reviews when left empty. Tags are separated by semicolons.
+var ExportColumns = []string{
+	"professor_id", "professor_name", "department",
+	"overall_rating", "would_take_again", "difficulty_level", "total_ratings",
+	"rating", "difficulty", "course_code", "date",
+	"for_credit", "attendance", "review_would_take_again", "grade", "textbook", "online_class",
+	"review_msg", "helpful", "not_helpful", "tags",
+}
+
+var fileNameUnsafe = regexp.MustCompile(`[^\p{L}\p{N}_\s-]`)
+
+// Issue is a problem found while validating an export. Errors drop the
+// instructor or review, warnings keep it.
+type Issue struct {
+	Error   bool
+	Subject string
+	Message string
+}
+
+func (i Issue) String() string {
+	level := "warning"
+	if i.Error {
+		level = "error"
+	}
+	return fmt.Sprintf("%s: %s: %s", level, i.Subject, i.Message)
+}
+
+// Artifacts is the review JSON tree built from an export.
+type Artifacts struct {
+	Instructors         map[string]model.InstructorReviewData // keyed by path relative to instructor_reviews
+	Courses             map[string]model.CourseReviewData     // keyed by course code
+	InstructorSummaries []model.ProfessorSummary
+	CourseSummaries     []model.CourseSummary
+}
+
+// ReadExportJSON reads an export in the format of the instructor review files,
+// as an array of instructors with their reviews.
+func ReadExportJSON(r io.Reader) ([]model.InstructorReviewData, error) {
+	var instructors []model.InstructorReviewData
+	if err := json.NewDecoder(r).Decode(&instructors); err != nil {
+		return nil, fmt.Errorf("error parsing JSON export: %v", err)
+	}
+	return instructors, nil
+}
+
+// ReadExportCSV reads an export with the ExportColumns header, in any order.
+// Rows are grouped into instructors by professor_id.
+func ReadExportCSV(r io.Reader) ([]model.InstructorReviewData, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV header: %v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"professor_id", "professor_name", "rating", "course_code", "date"} {
+		if _, found := columns[required]; !found {
+			return nil, fmt.Errorf("CSV export is missing the %s column", required)
+		}
+	}
+
+	var instructors []model.InstructorReviewData
+	byID := make(map[string]int)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading CSV line %d: %v", line, err)
+		}
+
+		get := func(column string) string {
+			if i, found := columns[column]; found && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		id := get("professor_id")
+		i, exists := byID[id]
+		if !exists {
+			i = len(instructors)
+			byID[id] = i
+			instructors = append(instructors, model.InstructorReviewData{
+				ProfessorID:     id,
+				ProfessorName:   get("professor_name"),
+				Department:      get("department"),
+				OverallRating:   get("overall_rating"),
+				WouldTakeAgain:  get("would_take_again"),
+				DifficultyLevel: get("difficulty_level"),
+				TotalRatings:    get("total_ratings"),
+			})
+		}
+
+		instructors[i].Reviews = append(instructors[i].Reviews, model.Review{
+			Rating:     get("rating"),
+			Difficulty: get("difficulty"),
+			CourseCode: get("course_code"),
+			Date:       get("date"),
+			Metadata: model.ReviewMetadata{
+				ForCredit:      get("for_credit"),
+				Attendance:     get("attendance"),
+				WouldTakeAgain: get("review_would_take_again"),
+				Grade:          get("grade"),
+				Textbook:       get("textbook"),
+				OnlineClass:    get("online_class"),
+			},
+			ReviewMsg:  get("review_msg"),
+			Helpful:    get("helpful"),
+			NotHelpful: get("not_helpful"),
+			Tags:       splitTags(get("tags")),
+		})
+	}
+
+	return instructors, nil
+}
+
+// Validate drops instructors without an ID or name, repeated instructors,
+// reviews with ratings outside 1 to 5 and repeated reviews, and fills in the
+// instructor overview fields missing from the export. Reviews without a date
+// or course code are kept with a warning.
+func Validate(instructors []model.InstructorReviewData) ([]model.InstructorReviewData, []Issue) {
+	var issues []Issue
+	var valid []model.InstructorReviewData
+	seenIDs := make(map[string]bool)
+
+	for i, instructor := range instructors {
+		subject := fmt.Sprintf("instructor %q (%s)", instructor.ProfessorName, instructor.ProfessorID)
+		if instructor.ProfessorID == "" || strings.TrimSpace(instructor.ProfessorName) == "" {
+			issues = append(issues, Issue{Error: true, Subject: fmt.Sprintf("instructor #%d", i+1), Message: "missing professor_id or professor_name"})
+			continue
+		}
+		if seenIDs[instructor.ProfessorID] {
+			issues = append(issues, Issue{Subject: subject, Message: "duplicate professor_id, keeping the first one"})
+			continue
+		}
+		seenIDs[instructor.ProfessorID] = true
+
+		seenReviews := make(map[string]bool)
+		reviews := []model.Review{}
+		for j, review := range instructor.Reviews {
+			reviewSubject := fmt.Sprintf("%s review #%d", subject, j+1)
+			if message := validateScore("rating", review.Rating, true); message != "" {
+				issues = append(issues, Issue{Error: true, Subject: reviewSubject, Message: message})
+				continue
+			}
+			if message := validateScore("difficulty", review.Difficulty, false); message != "" {
+				issues = append(issues, Issue{Error: true, Subject: reviewSubject, Message: message})
+				continue
+			}
+
+			key := dedupeKey(review)
+			if seenReviews[key] {
+				issues = append(issues, Issue{Subject: reviewSubject, Message: "duplicate review dropped"})
+				continue
+			}
+			seenReviews[key] = true
+
+			if _, ok := ParseDate(review.Date); !ok {
+				issues = append(issues, Issue{Subject: reviewSubject, Message: fmt.Sprintf("unparseable date %q", review.Date)})
+			}
+			if NormalizeCourseCode(review.CourseCode) == "" {
+				issues = append(issues, Issue{Subject: reviewSubject, Message: "missing course code, left out of course files"})
+			}
+
+			review.DateISO = ""
+			review.Tags = lo.Map(review.Tags, func(tag string, _ int) string { return strings.TrimSpace(tag) })
+			reviews = append(reviews, review)
+		}
+
+		instructor.InstructorID = ""
+		instructor.Page = nil
+		instructor.Reviews = reviews
+		fillOverview(&instructor)
+		valid = append(valid, instructor)
+	}
+
+	return valid, issues
+}
+
+// BuildArtifacts builds the instructor files, course files and both overviews
+// from validated instructors. Instructors whose file paths clash get their
+// professor ID appended to the file name.
+func BuildArtifacts(instructors []model.InstructorReviewData) (Artifacts, []Issue) {
+	artifacts := Artifacts{
+		Instructors: make(map[string]model.InstructorReviewData),
+		Courses:     make(map[string]model.CourseReviewData),
+	}
+	var issues []Issue
+
+	for _, instructor := range instructors {
+		filePath := InstructorFilePath(instructor)
+		if _, exists := artifacts.Instructors[filePath]; exists {
+			clash := filePath
+			filePath = strings.TrimSuffix(filePath, ".json") + "_" + instructor.ProfessorID + ".json"
+			issues = append(issues, Issue{
+				Subject: fmt.Sprintf("instructor %q (%s)", instructor.ProfessorName, instructor.ProfessorID),
+				Message: fmt.Sprintf("%s is taken, writing %s", clash, filePath),
+			})
+		}
+		artifacts.Instructors[filePath] = instructor
+
+		artifacts.InstructorSummaries = append(artifacts.InstructorSummaries, model.ProfessorSummary{
+			URL:            "https://www.ratemyprofessors.com/professor/" + instructor.ProfessorID,
+			Quality:        instructor.OverallRating,
+			Ratings:        instructor.TotalRatings,
+			Name:           instructor.ProfessorName,
+			WouldTakeAgain: percentLabel(instructor.WouldTakeAgain),
+			Difficulty:     instructor.DifficultyLevel,
+			Department:     departmentName(instructor.Department),
+		})
+
+		byCourse := lo.GroupBy(lo.Filter(instructor.Reviews, func(review model.Review, _ int) bool {
+			return NormalizeCourseCode(review.CourseCode) != ""
+		}), func(review model.Review) string {
+			return NormalizeCourseCode(review.CourseCode)
+		})
+		for code, courseReviews := range byCourse {
+			ratings, difficulties := scores(courseReviews)
+			course := artifacts.Courses[code]
+			course.CourseCode = code
+			course.TotalReviews += len(courseReviews)
+			course.Instructors = append(course.Instructors, model.InstructorSummary{
+				ProfessorID:    instructor.ProfessorID,
+				ProfessorName:  instructor.ProfessorName,
+				Department:     departmentName(instructor.Department),
+				AvgRating:      average(ratings),
+				AvgDifficulty:  average(difficulties),
+				ReviewCount:    len(courseReviews),
+				WouldTakeAgain: percentLabel(wouldTakeAgainPercent(courseReviews)),
+				Reviews:        courseReviews,
+			})
+			artifacts.Courses[code] = course
+		}
+	}
+
+	for code, course := range artifacts.Courses {
+		// Most reviewed instructors first
+		sort.SliceStable(course.Instructors, func(i, j int) bool {
+			return course.Instructors[i].ReviewCount > course.Instructors[j].ReviewCount
+		})
+
+		var ratings, difficulties []float64
+		for _, instructor := range course.Instructors {
+			r, d := scores(instructor.Reviews)
+			ratings, difficulties = append(ratings, r...), append(difficulties, d...)
+		}
+		course.AvgRating = average(ratings)
+		course.AvgDifficulty = average(difficulties)
+		artifacts.Courses[code] = course
+
+		artifacts.CourseSummaries = append(artifacts.CourseSummaries, model.CourseSummary{
+			CourseCode:    code,
+			TotalReviews:  course.TotalReviews,
+			AvgRating:     course.AvgRating,
+			AvgDifficulty: course.AvgDifficulty,
+		})
+	}
+
+	sort.SliceStable(artifacts.InstructorSummaries, func(i, j int) bool {
+		a, b := artifacts.InstructorSummaries[i], artifacts.InstructorSummaries[j]
+		if a.Department != b.Department {
+			return a.Department < b.Department
+		}
+		return a.Name < b.Name
+	})
+	sort.Slice(artifacts.CourseSummaries, func(i, j int) bool {
+		return artifacts.CourseSummaries[i].CourseCode < artifacts.CourseSummaries[j].CourseCode
+	})
+
+	return artifacts, issues
+}
+
+// InstructorFilePath returns the review file path of an instructor relative to
+// the instructor_reviews directory.
+// Example: InstructorFilePath({ProfessorName: "D. C. Merrett", Department: "Archaeology department"}) returns "Archaeology/D_C_Merrett.json"
+func InstructorFilePath(instructor model.InstructorReviewData) string {
+	name := strings.Join(strings.Fields(fileNameUnsafe.ReplaceAllString(instructor.ProfessorName, "")), "_")
+	dept := departmentName(instructor.Department)
+	if dept == "" {
+		dept = "Unknown"
+	}
+	return path.Join(strings.ReplaceAll(dept, "/", "-"), name+".json")
+}
+
+// fillOverview computes the overview fields missing from the export, leaving
+// them empty for instructors without reviews.
+func fillOverview(instructor *model.InstructorReviewData) {
+	if len(instructor.Reviews) == 0 {
+		return
+	}
+
+	ratings, difficulties := scores(instructor.Reviews)
+	if ParseFloat(instructor.OverallRating) == nil {
+		instructor.OverallRating = scoreLabel(ratings)
+	}
+	if ParseFloat(instructor.DifficultyLevel) == nil {
+		instructor.DifficultyLevel = scoreLabel(difficulties)
+	}
+	if ParseFloat(instructor.WouldTakeAgain) == nil {
+		instructor.WouldTakeAgain = wouldTakeAgainPercent(instructor.Reviews)
+	}
+	if ParseInt(instructor.TotalRatings) == nil {
+		instructor.TotalRatings = fmt.Sprint(len(instructor.Reviews))
+	}
+}
+
+func validateScore(name, value string, required bool) string {
+	if strings.TrimSpace(value) == "" {
+		if required {
+			return "missing " + name
+		}
+		return ""
+	}
+	score := ParseFloat(value)
+	if score == nil || *score < 1 || *score > 5 {
+		return fmt.Sprintf("%s %q is not between 1 and 5", name, value)
+	}
+	return ""
+}
+
+// wouldTakeAgainPercent returns the rounded percentage of yes answers, or
+// "N/A" if no review answered.
+func wouldTakeAgainPercent(reviews []model.Review) string {
+	var answers yesNoCounter
+	for _, review := range reviews {
+		answers.add(review.Metadata.WouldTakeAgain, "Yes", "No")
+	}
+	if answers.yes+answers.no == 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.0f", float64(answers.yes)/float64(answers.yes+answers.no)*100)
+}
+
+// percentLabel writes a percentage the way the overview does, e.g. "85%".
+func percentLabel(value string) string {
+	if ParseFloat(value) == nil {
+		return "N/A"
+	}
+	return strings.TrimSuffix(value, "%") + "%"
+}
+
+func scoreLabel(values []float64) string {
+	if len(values) == 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.1f", lo.Sum(values)/float64(len(values)))
+}
+
+// departmentName strips the suffix of RateMyProfessors department names.
+// Example: departmentName("Accounting department") returns "Accounting"
+func departmentName(department string) string {
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(department), " department"))
+}
+
+func splitTags(tags string) []string {
+	result := []string{}
+	for _, tag := range strings.Split(tags, ";") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
@@ -0,0 +1,166 @@
+package reviews
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/samber/lo"
+)
+
+// DefaultKeywords is the number of keywords kept per instructor and per course.
+const DefaultKeywords = 10
+
+const (
+	// minKeywordReviews is the number of reviews of an instructor or course
+	// that must mention a phrase for it to be a keyword.
+	minKeywordReviews = 2
+	// bigramBoost favours phrases such as "heavy workload" over single words.
+	bigramBoost = 1.5
+)
+
+// stopwords break phrases and are never keywords. Besides common English
+// words, they include words found in nearly every review.
+var stopwords = toSet(strings.Fields(`
+	a about above after again against all also am an and any are aren't as at be because been before
+	being below between both but by can can't cannot could couldn't did didn't do does doesn't doing
+	don't down during each even ever every few for from further get gets got had hadn't has hasn't have
+	haven't having he he'd he'll he's her here here's hers herself him himself his how how's however i
+	i'd i'll i'm i've if in into is isn't it it's its itself just let's lot lots me more most much
+	mustn't my myself no nor not of off on once only or other ought our ours ourselves out over own
+	pretty quite rather really same shan't she she'd she'll she's should shouldn't so some still such
+	than that that's the their theirs them themselves then there there's these they they'd they'll
+	they're they've this those though through to too under until up us very was wasn't way we we'd
+	we'll we're we've well were weren't what what's when when's where where's which while who who's
+	whom why why's will with won't would wouldn't you you'd you'll you're you've your yours yourself
+	yourselves
+	class classes course courses prof profs professor professors teacher instructor sfu lecture
+	lectures take took taking taken semester one two make makes made thing things like
+`))
+
+// KeywordIndex scores review phrases by TF-IDF. Each document of the corpus
+// is the reviews of one instructor or one course, so phrases found in most
+// documents, such as "good" or "exams", score low.
+type KeywordIndex struct {
+	docFreq  map[string]int
+	mentions map[string]map[string]int // document key to reviews mentioning each phrase
+	reviews  map[string]int            // document key to review count
+}
+
+// NewKeywordIndex creates an empty index.
+func NewKeywordIndex() *KeywordIndex {
+	return &KeywordIndex{
+		docFreq:  make(map[string]int),
+		mentions: make(map[string]map[string]int),
+		reviews:  make(map[string]int),
+	}
+}
+
+// Add adds the reviews of one instructor or course to the corpus, under a key
+// that identifies the document in Keywords.
+func (k *KeywordIndex) Add(key string, reviews []model.Review) {
+	counts := mentions(reviews)
+	k.mentions[key] = counts
+	k.reviews[key] = len(reviews)
+	for phrase := range counts {
+		k.docFreq[phrase]++
+	}
+}
+
+// Keywords returns the most distinctive phrases of a document. A phrase's term
+// frequency is the share of reviews mentioning it. Phrases containing an
+// excluded word, such as the instructor's name, are skipped, and words of a
+// chosen phrase aren't repeated on their own.
+func (k *KeywordIndex) Keywords(key string, exclude []string, limit int) []model.Keyword {
+	excluded := make(map[string]bool)
+	for _, word := range exclude {
+		addPhrases(excluded, word)
+	}
+
+	counts, total := k.mentions[key], k.reviews[key]
+	docs := len(k.mentions)
+
+	candidates := make([]model.Keyword, 0, len(counts))
+	for phrase, count := range counts {
+		if count < minKeywordReviews || lo.SomeBy(strings.Fields(phrase), func(word string) bool { return excluded[word] }) {
+			continue
+		}
+		idf := math.Log(float64(docs+1) / float64(k.docFreq[phrase]+1))
+		score := float64(count) / float64(total) * idf
+		if strings.Contains(phrase, " ") {
+			score *= bigramBoost
+		}
+		if score <= 0 {
+			continue
+		}
+		candidates = append(candidates, model.Keyword{Phrase: phrase, Count: count, Score: math.Round(score*10000) / 10000})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Phrase < candidates[j].Phrase
+	})
+
+	keywords := []model.Keyword{}
+	covered := make(map[string]bool)
+	for _, candidate := range candidates {
+		if len(keywords) == limit {
+			break
+		}
+		words := strings.Fields(candidate.Phrase)
+		if len(words) == 1 && covered[words[0]] {
+			continue
+		}
+		for _, word := range words {
+			covered[word] = true
+		}
+		keywords = append(keywords, candidate)
+	}
+	return keywords
+}
+
+// mentions counts the reviews mentioning every phrase.
+func mentions(reviews []model.Review) map[string]int {
+	counts := make(map[string]int)
+	found := make(map[string]bool)
+	for _, review := range reviews {
+		clear(found)
+		addPhrases(found, review.ReviewMsg)
+		for phrase := range found {
+			counts[phrase]++
+		}
+	}
+	return counts
+}
+
+// addPhrases adds the words and two-word phrases of a text to a set,
+// lowercased. Stopwords, numbers and punctuation end a phrase.
+func addPhrases(set map[string]bool, text string) {
+	previous := ""
+	for _, token := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	}) {
+		token = strings.Trim(token, "'")
+		if len(token) < 3 || stopwords[token] {
+			previous = ""
+			continue
+		}
+		set[token] = true
+		if previous != "" {
+			set[previous+" "+token] = true
+		}
+		previous = token
+	}
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
@@ -0,0 +1,162 @@
+package reviews
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/samber/lo"
+)
+
+// Sort keys accepted by Query.Sort.
+const (
+	SortDate       = "date"
+	SortHelpful    = "helpful"
+	SortRating     = "rating"
+	SortDifficulty = "difficulty"
+)
+
+// Query filters, sorts and paginates individual reviews. Zero values match
+// everything and keep the file order.
+type Query struct {
+	CourseCode     string
+	From           time.Time // inclusive
+	To             time.Time // inclusive
+	Grades         []string  // any of
+	Tags           []string  // all of
+	WouldTakeAgain string    // "yes" or "no"
+	OnlineClass    string    // "yes" or "no"
+	Sort           string
+	Ascending      bool
+	Limit          int
+	Offset         int
+}
+
+// IsZero reports whether the query leaves reviews untouched.
+func (q Query) IsZero() bool {
+	return !q.HasFilters() && q.Sort == "" && q.Limit == 0 && q.Offset == 0
+}
+
+// HasFilters reports whether the query drops any reviews before pagination.
+func (q Query) HasFilters() bool {
+	return q.CourseCode != "" || !q.From.IsZero() || !q.To.IsZero() || len(q.Grades) > 0 ||
+		len(q.Tags) > 0 || q.WouldTakeAgain != "" || q.OnlineClass != ""
+}
+
+// Apply returns the page of reviews matching the query, along with the number
+// of matching reviews before pagination. The input slice is not modified.
+func Apply(reviews []model.Review, q Query) ([]model.Review, int) {
+	matched := lo.Filter(reviews, func(review model.Review, _ int) bool {
+		return q.matches(review)
+	})
+
+	if q.Sort != "" {
+		sortReviews(matched, q.Sort, q.Ascending)
+	}
+
+	total := len(matched)
+	start := min(q.Offset, total)
+	end := total
+	if q.Limit > 0 {
+		end = min(start+q.Limit, total)
+	}
+	return matched[start:end], total
+}
+
+func (q Query) matches(review model.Review) bool {
+	if q.CourseCode != "" && NormalizeCourseCode(review.CourseCode) != NormalizeCourseCode(q.CourseCode) {
+		return false
+	}
+
+	if !q.From.IsZero() || !q.To.IsZero() {
+		date, ok := reviewDate(review)
+		if !ok || (!q.From.IsZero() && date.Before(q.From)) || (!q.To.IsZero() && date.After(q.To)) {
+			return false
+		}
+	}
+
+	if len(q.Grades) > 0 && !lo.ContainsBy(q.Grades, func(grade string) bool {
+		return normalizeLabel(grade) == normalizeLabel(review.Metadata.Grade)
+	}) {
+		return false
+	}
+
+	for _, tag := range q.Tags {
+		if !lo.ContainsBy(review.Tags, func(reviewTag string) bool {
+			return strings.EqualFold(strings.TrimSpace(reviewTag), tag)
+		}) {
+			return false
+		}
+	}
+
+	if q.WouldTakeAgain != "" && !strings.EqualFold(review.Metadata.WouldTakeAgain, q.WouldTakeAgain) {
+		return false
+	}
+
+	// Online Class is only set on reviews of online classes, so "no" matches unset
+	if q.OnlineClass != "" && strings.EqualFold(review.Metadata.OnlineClass, "yes") != strings.EqualFold(q.OnlineClass, "yes") {
+		return false
+	}
+
+	return true
+}
+
+// sortReviews sorts reviews in place, most recent first among equal values.
+func sortReviews(reviews []model.Review, key string, ascending bool) {
+	type sortable struct {
+		review model.Review
+		value  float64
+		date   time.Time
+	}
+
+	items := lo.Map(reviews, func(review model.Review, _ int) sortable {
+		item := sortable{review: review}
+		item.date, _ = reviewDate(review)
+		switch key {
+		case SortDate:
+			item.value = float64(item.date.Unix())
+		case SortHelpful:
+			item.value = parseNumber(review.Helpful) - parseNumber(review.NotHelpful)
+		case SortRating:
+			item.value = parseNumber(review.Rating)
+		case SortDifficulty:
+			item.value = parseNumber(review.Difficulty)
+		}
+		return item
+	})
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].value != items[j].value {
+			if ascending {
+				return items[i].value < items[j].value
+			}
+			return items[i].value > items[j].value
+		}
+		return items[i].date.After(items[j].date)
+	})
+
+	for i, item := range items {
+		reviews[i] = item.review
+	}
+}
+
+// NormalizeCourseCode removes spaces and uppercases a course code.
+// Example: NormalizeCourseCode("cmpt 225") returns "CMPT225"
+func NormalizeCourseCode(courseCode string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(courseCode), ""))
+}
+
+// normalizeLabel makes "Not_Sure_Yet" and "Not sure yet" compare equal.
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(strings.ReplaceAll(label, "_", " ")))
+}
+
+func parseNumber(value string) float64 {
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0
+	}
+	return number
+}
@@ -0,0 +1,312 @@
+package reviews
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/samber/lo"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := map[string]string{
+		"Sep 1st, 2020":  "2020-09-01",
+		"Dec 22nd, 2019": "2019-12-22",
+		"Apr 3rd, 2021":  "2021-04-03",
+		"Jan 13th, 2025": "2025-01-13",
+	}
+	for input, want := range tests {
+		got, ok := ParseDate(input)
+		if !ok || got.Format(DateLayout) != want {
+			t.Errorf("ParseDate(%q) = %v, %v, want %s", input, got, ok, want)
+		}
+	}
+	if _, ok := ParseDate("yesterday"); ok {
+		t.Errorf("ParseDate(\"yesterday\") succeeded, want failure")
+	}
+}
+
+func TestApply(t *testing.T) {
+	all := []model.Review{
+		{Rating: "5.0", CourseCode: "CMPT225", Date: "Sep 1st, 2020", Helpful: "3", Metadata: model.ReviewMetadata{Grade: "A"}, Tags: []string{"Caring "}},
+		{Rating: "2.0", CourseCode: "cmpt 225", Date: "Jan 5th, 2023", Helpful: "10", Metadata: model.ReviewMetadata{Grade: "B", OnlineClass: "Yes"}},
+		{Rating: "4.0", CourseCode: "CMPT307", Date: "Mar 9th, 2022", Metadata: model.ReviewMetadata{Grade: "Not_Sure_Yet"}, Tags: []string{"Caring"}},
+	}
+
+	page, total := Apply(all, Query{CourseCode: "CMPT 225"})
+	if total != 2 || len(page) != 2 {
+		t.Errorf("course filter matched %d, want 2", total)
+	}
+
+	page, total = Apply(all, Query{From: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), Tags: []string{"caring"}})
+	if total != 1 || page[0].CourseCode != "CMPT307" {
+		t.Errorf("date and tag filter = %v, want the CMPT307 review", page)
+	}
+
+	if _, total = Apply(all, Query{Grades: []string{"not sure yet", "A"}, OnlineClass: "no"}); total != 2 {
+		t.Errorf("grade and online filter matched %d, want 2", total)
+	}
+
+	page, total = Apply(all, Query{Sort: SortRating, Limit: 1, Offset: 1})
+	if total != 3 || len(page) != 1 || page[0].Rating != "4.0" {
+		t.Errorf("sorted page = %v, want the 4.0 review", page)
+	}
+
+	page, _ = Apply(all, Query{Sort: SortDate, Ascending: true})
+	if page[0].Date != "Sep 1st, 2020" || all[0].Date != "Sep 1st, 2020" || all[1].Rating != "2.0" {
+		t.Errorf("date sort = %v, want oldest first without modifying the input", page)
+	}
+}
+
+func TestBuildStats(t *testing.T) {
+	all := []model.Review{
+		{Rating: "4.5", Difficulty: "3.0", Metadata: model.ReviewMetadata{Grade: "Not_Sure_Yet", Textbook: "Yes", Attendance: "Mandatory"}, Tags: []string{"Caring", "caring "}},
+		{Rating: "2.0", Difficulty: "5.0", Metadata: model.ReviewMetadata{Grade: "A-", Textbook: "N/A", WouldTakeAgain: "No"}, Tags: []string{"Tough grader"}},
+		{Rating: "5.0", Difficulty: "3.0", Metadata: model.ReviewMetadata{Grade: "Not sure yet", Textbook: "No"}, Tags: []string{"Caring"}},
+	}
+
+	stats := BuildStats(all, 1)
+	if stats.TotalReviews != 3 || stats.AvgRating != 3.83 || stats.AvgDifficulty != 3.67 {
+		t.Errorf("totals = %d, %v, %v, want 3, 3.83, 3.67", stats.TotalReviews, stats.AvgRating, stats.AvgDifficulty)
+	}
+	if len(stats.RatingHistogram) != 9 || stats.RatingHistogram[7].Value != 4.5 || stats.RatingHistogram[7].Count != 1 {
+		t.Errorf("rating histogram = %v, want one review at 4.5", stats.RatingHistogram)
+	}
+	if len(stats.GradeDistribution) != 2 || stats.GradeDistribution[0].Label != "A-" || stats.GradeDistribution[1].Label != "Not sure yet" || stats.GradeDistribution[1].Count != 2 {
+		t.Errorf("grade distribution = %v, want A- then 2 x Not sure yet", stats.GradeDistribution)
+	}
+	if len(stats.TopTags) != 1 || stats.TopTags[0].Label != "Caring" || stats.TopTags[0].Count != 2 {
+		t.Errorf("top tags = %v, want Caring counted once per review", stats.TopTags)
+	}
+	if stats.TextbookRate.Rate != 0.5 || stats.TextbookRate.Answered != 2 {
+		t.Errorf("textbook rate = %v, want 0.5 of 2", stats.TextbookRate)
+	}
+	if stats.WouldTakeAgainRate.Rate != 0 || stats.WouldTakeAgainRate.Answered != 1 {
+		t.Errorf("would take again rate = %v, want 0 of 1", stats.WouldTakeAgainRate)
+	}
+}
+
+func TestBuildTrend(t *testing.T) {
+	all := []model.Review{
+		{Date: "Sep 1st, 2023", Rating: "2.0", Difficulty: "4.0"},
+		{Date: "Dec 10th, 2023", Rating: "3.0", Difficulty: "4.0"},
+		{Date: "Jun 2nd, 2024", Rating: "5.0", Difficulty: "2.0"},
+		{Date: "not a date", Rating: "1.0"},
+	}
+
+	points := BuildTrend(all, PeriodTerm, 2)
+	if len(points) != 3 || points[0].Period != "2023-fall" || points[2].Period != "2024-summer" {
+		t.Fatalf("periods = %v, want 2023-fall through 2024-summer", points)
+	}
+	if points[0].Reviews != 2 || *points[0].AvgRating != 2.5 {
+		t.Errorf("2023-fall = %+v, want 2 reviews averaging 2.5", points[0])
+	}
+	if points[1].AvgRating != nil || *points[1].RollingRating != 2.5 {
+		t.Errorf("2024-spring gap = %+v, want no average and a rolling rating of 2.5", points[1])
+	}
+	if *points[2].RollingRating != 5 || *points[2].RollingDifficulty != 2 {
+		t.Errorf("2024-summer = %+v, want rolling averages of 5 and 2", points[2])
+	}
+
+	years := BuildTrend(all, PeriodYear, 3)
+	if len(years) != 2 || years[1].Period != "2024" || *years[1].RollingRating != 3.33 {
+		t.Errorf("years = %v, want 2023 and 2024 with a rolling rating of 3.33", years)
+	}
+}
+
+func TestInstructorV2(t *testing.T) {
+	data := InstructorV2(model.InstructorReviewData{
+		OverallRating:  "N/A",
+		WouldTakeAgain: "85",
+		TotalRatings:   "13",
+		Reviews: []model.Review{{
+			Rating:     "4.0",
+			Difficulty: "0",
+			Date:       "Sep 1st, 2020",
+			Helpful:    "5",
+			Metadata:   model.ReviewMetadata{Attendance: "Not Mandatory", Textbook: "N/A", Grade: "Not_Sure_Yet"},
+			Tags:       []string{"Caring "},
+		}},
+	})
+
+	if data.OverallRating != nil || *data.WouldTakeAgain != 85 || *data.TotalRatings != 13 || data.DifficultyLevel != nil {
+		t.Errorf("instructor = %+v, want null rating and difficulty, 85 and 13", data)
+	}
+
+	review := data.Reviews[0]
+	if *review.Rating != 4 || review.Difficulty != nil || *review.Date != "2020-09-01" || review.Helpful != 5 {
+		t.Errorf("review = %+v, want rating 4, null difficulty, 2020-09-01 and 5 helpful votes", review)
+	}
+	if *review.AttendanceMandatory || review.Textbook != nil || *review.Grade != "Not sure yet" || review.Tags[0] != "Caring" {
+		t.Errorf("review metadata = %+v, want attendance false, null textbook, Not sure yet and Caring", review)
+	}
+
+	course := CourseV2(model.CourseReviewData{Instructors: []model.InstructorSummary{{AvgRating: 4.5, AvgDifficulty: 2}, {}}})
+	if *course.Instructors[0].AvgRating != 4.5 || course.Instructors[1].AvgRating != nil || course.Instructors[1].AvgDifficulty != nil {
+		t.Errorf("course instructors = %+v, want 4.5 and null averages without ratings", course.Instructors)
+	}
+
+	summaries := ProfessorSummariesV2([]model.ProfessorSummary{{Quality: "3.1", WouldTakeAgain: "50%", Ratings: "14"}, {WouldTakeAgain: "N/A"}})
+	if *summaries[0].WouldTakeAgain != 50 || summaries[0].Ratings != 14 || summaries[1].WouldTakeAgain != nil {
+		t.Errorf("summaries = %+v, want 50, 14 ratings and null", summaries)
+	}
+}
+
+func TestAggregateCourse(t *testing.T) {
+	shared := model.Review{Date: "Apr 3rd, 2015", Rating: "3.0", Difficulty: "2.0", ReviewMsg: "Took this course last semester."}
+	files := []model.CourseReviewData{
+		{CourseCode: "CMPT225", TotalReviews: 3, Instructors: []model.InstructorSummary{
+			{InstructorID: "bkinney", ProfessorID: "1", ProfessorName: "Bryan Kinney", ReviewCount: 2, Reviews: []model.Review{shared, shared}},
+			{ProfessorName: "Jane Doe", ReviewCount: 1, Reviews: []model.Review{{Rating: "5.0", Difficulty: "4.0"}}},
+		}},
+		{CourseCode: "cmpt225", TotalReviews: 2, Instructors: []model.InstructorSummary{
+			{InstructorID: "bkinney", ProfessorID: "2", ProfessorName: "Bryan Kinney", ReviewCount: 3, Reviews: []model.Review{shared, {Rating: "1.0", Difficulty: "5.0"}}},
+		}},
+	}
+
+	course := AggregateCourse("CMPT225", files)
+	if len(course.Instructors) != 2 || course.TotalReviews != 3 {
+		t.Fatalf("course = %+v, want 2 instructors and 3 unique reviews", course)
+	}
+
+	kinney := course.Instructors[0]
+	if kinney.ProfessorID != "2" || kinney.ReviewCount != 2 || kinney.AvgRating != 2 || kinney.AvgDifficulty != 3.5 {
+		t.Errorf("merged instructor = %+v, want profile 2 with 2 reviews averaging 2 and 3.5", kinney)
+	}
+	if course.AvgRating != 3 || course.AvgDifficulty != 3.67 {
+		t.Errorf("course averages = %v, %v, want 3 and 3.67", course.AvgRating, course.AvgDifficulty)
+	}
+}
+
+func TestAggregateInstructor(t *testing.T) {
+	shared := model.Review{Date: "Apr 3rd, 2015", Rating: "3.0", Difficulty: "2.0", ReviewMsg: "Took this course last semester."}
+	files := []model.InstructorReviewData{
+		{ProfessorID: "1", ProfessorName: "Bryan Kinney", OverallRating: "3.0", TotalRatings: "2", Reviews: []model.Review{
+			shared,
+			{Date: "May 1st, 2016", Rating: "5.0", Difficulty: "4.0", Metadata: model.ReviewMetadata{WouldTakeAgain: "Yes"}},
+		}},
+		{ProfessorID: "2", ProfessorName: "B. Kinney", OverallRating: "2.0", TotalRatings: "2", Reviews: []model.Review{
+			shared,
+			{Date: "Jan 9th, 2017", Rating: "1.0", Difficulty: "3.0", Metadata: model.ReviewMetadata{WouldTakeAgain: "No"}},
+		}},
+	}
+
+	got := AggregateInstructor(files)
+	if got.ProfessorID != "1" || len(got.Reviews) != 3 || got.TotalRatings != "3" {
+		t.Fatalf("merged instructor = %+v, want profile 1 with 3 unique reviews", got)
+	}
+	if got.OverallRating != "3.0" || got.DifficultyLevel != "3.0" || got.WouldTakeAgain != "50" {
+		t.Errorf("overview = %s, %s, %s, want 3.0, 3.0 and 50", got.OverallRating, got.DifficultyLevel, got.WouldTakeAgain)
+	}
+	if single := AggregateInstructor(files[:1]); single.OverallRating != "3.0" || single.TotalRatings != "2" {
+		t.Errorf("single file = %+v, want it unchanged", single)
+	}
+}
+
+func TestIngestCSV(t *testing.T) {
+	export := `professor_id,professor_name,department,rating,difficulty,course_code,date,review_would_take_again,review_msg,tags
+1,D. C. Merrett,Archaeology department,4.0,3.0,ARCH 201,"Sep 1st, 2020",Yes,Great,Caring ;Clear grading criteria
+1,D. C. Merrett,Archaeology department,4.0,3.0,ARCH 201,"Sep 1st, 2020",Yes,Great,Caring ;Clear grading criteria
+1,D. C. Merrett,Archaeology department,2.0,4.0,arch201,"Jan 5th, 2021",No,Hard,
+1,D. C. Merrett,Archaeology department,9.0,4.0,ARCH201,"Jan 5th, 2021",No,Typo,
+,Nobody,,5.0,1.0,ARCH201,"Jan 5th, 2021",Yes,Missing ID,
+`
+
+	instructors, err := ReadExportCSV(strings.NewReader(export))
+	if err != nil {
+		t.Fatalf("ReadExportCSV() error = %v", err)
+	}
+
+	valid, issues := Validate(instructors)
+	errorCount := lo.CountBy(issues, func(i Issue) bool { return i.Error })
+	if len(valid) != 1 || len(valid[0].Reviews) != 2 || errorCount != 2 || len(issues) != 3 {
+		t.Fatalf("Validate() = %d instructors, issues %v, want 1 instructor with 2 reviews, 2 errors and a duplicate warning", len(valid), issues)
+	}
+	if valid[0].OverallRating != "3.0" || valid[0].WouldTakeAgain != "50" || valid[0].TotalRatings != "2" {
+		t.Errorf("overview = %+v, want 3.0, 50 and 2 computed from the reviews", valid[0])
+	}
+
+	artifacts, _ := BuildArtifacts(valid)
+	if _, found := artifacts.Instructors["Archaeology/D_C_Merrett.json"]; !found {
+		t.Errorf("instructor files = %v, want Archaeology/D_C_Merrett.json", lo.Keys(artifacts.Instructors))
+	}
+	course, found := artifacts.Courses["ARCH201"]
+	if !found || course.TotalReviews != 2 || course.AvgRating != 3 || course.Instructors[0].WouldTakeAgain != "50%" {
+		t.Errorf("courses = %+v, want ARCH201 with 2 reviews averaging 3", artifacts.Courses)
+	}
+	if len(artifacts.InstructorSummaries) != 1 || artifacts.InstructorSummaries[0].Department != "Archaeology" || artifacts.InstructorSummaries[0].WouldTakeAgain != "50%" {
+		t.Errorf("instructor summaries = %+v, want Archaeology with 50%%", artifacts.InstructorSummaries)
+	}
+}
+
+func TestCatalogResolve(t *testing.T) {
+	catalog := NewCatalog([]model.CourseOutline{
+		{Dept: "CMPT", Number: "225"},
+		{Dept: "BUS", Number: "360W"},
+		{Dept: "BPK", Number: "140"},
+		{Dept: "MATH", Number: "225"},
+	})
+
+	tests := []struct {
+		code   string
+		depts  []string
+		want   string
+		reason string
+	}{
+		{"cmpt-225", nil, "CMPT225", ""},
+		{"CMPT225L", nil, "CMPT225", ""},
+		{"BUS 360", nil, "BUS360W", ""},
+		{"KIN140", nil, "BPK140", ""},
+		{"225", []string{"CMPT", "MATH"}, "CMPT225", ""},
+		{"225", []string{"BUS", "MATH"}, "MATH225", ""},
+		{"225", nil, "", ReasonNoDept},
+		{"CMPT165", nil, "", ReasonNotInCatalog},
+		{"BUEC232", nil, "", ReasonUnknownDept},
+		{"PHILXX1", nil, "", ReasonMalformed},
+	}
+
+	for _, tt := range tests {
+		got, reason := catalog.Resolve(tt.code, tt.depts)
+		if got != tt.want || reason != tt.reason {
+			t.Errorf("Resolve(%q, %v) = %q, %q, want %q, %q", tt.code, tt.depts, got, reason, tt.want, tt.reason)
+		}
+	}
+
+	reviews := []model.Review{{CourseCode: "CMPT 225"}, {CourseCode: "CMPT225"}, {CourseCode: "225"}, {CourseCode: "???"}}
+	hints := catalog.DeptHints(reviews)
+	unresolved := catalog.ResolveCourseCodes(reviews, hints)
+	if len(hints) != 1 || reviews[0].CourseCodeRaw != "CMPT 225" || reviews[1].CourseCodeRaw != "" || reviews[2].CourseCode != "CMPT225" || unresolved[3] != ReasonMalformed {
+		t.Errorf("ResolveCourseCodes() = %+v, %v, want CMPT225 for the first three reviews", reviews, unresolved)
+	}
+}
+
+func TestKeywords(t *testing.T) {
+	reviewsOf := func(messages ...string) []model.Review {
+		return lo.Map(messages, func(msg string, _ int) model.Review { return model.Review{ReviewMsg: msg} })
+	}
+
+	index := NewKeywordIndex()
+	index.Add("curved", reviewsOf(
+		"Great lectures and curved exams. Smith is great.",
+		"The curved exams saved me, lectures were great.",
+		"Great prof, heavy workload but curved exams.",
+	))
+	index.Add("workload", reviewsOf("Heavy workload, great lectures.", "Great notes."))
+	index.Add("other", reviewsOf("Great lectures.", "Great notes, great exams."))
+
+	keywords := index.Keywords("curved", []string{"John Smith"}, 3)
+	phrases := lo.Map(keywords, func(k model.Keyword, _ int) string { return k.Phrase })
+	if len(phrases) == 0 || phrases[0] != "curved exams" {
+		t.Errorf("Keywords() = %v, want \"curved exams\" first", phrases)
+	}
+	if lo.Contains(phrases, "curved") || lo.Contains(phrases, "smith") {
+		t.Errorf("Keywords() = %v, want no words of chosen phrases or excluded names", phrases)
+	}
+	if lo.Contains(phrases, "great") {
+		t.Errorf("Keywords() = %v, want no phrases found in every document", phrases)
+	}
+	if keywords[0].Count != 3 {
+		t.Errorf("Keywords()[0].Count = %d, want 3", keywords[0].Count)
+	}
+}
@@ -0,0 +1,174 @@
+package reviews
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/samber/lo"
+)
+
+// DefaultTopTags is the number of tags returned by BuildStats by default.
+const DefaultTopTags = 10
+
+// gradeOrder lists letter grades from best to worst. Other answers such as
+// "Not sure yet" follow, most frequent first.
+var gradeOrder = []string{"A+", "A", "A-", "B+", "B", "B-", "C+", "C", "C-", "D+", "D", "D-", "F", "Pass", "Fail"}
+
+// BuildStats computes rating, difficulty, grade and tag distributions.
+// Tags are limited to the topTags most frequent ones.
+func BuildStats(reviews []model.Review, topTags int) model.ReviewStats {
+	stats := model.ReviewStats{TotalReviews: len(reviews)}
+
+	ratings := make([]float64, 0, len(reviews))
+	difficulties := make([]float64, 0, len(reviews))
+	grades := newLabelCounter()
+	tags := newLabelCounter()
+	var textbook, attendance, wouldTakeAgain yesNoCounter
+
+	for _, review := range reviews {
+		if rating, ok := parseScore(review.Rating); ok {
+			ratings = append(ratings, rating)
+		}
+		if difficulty, ok := parseScore(review.Difficulty); ok {
+			difficulties = append(difficulties, difficulty)
+		}
+
+		grades.add(review.Metadata.Grade)
+		for _, tag := range lo.UniqBy(review.Tags, normalizeLabel) {
+			tags.add(tag)
+		}
+
+		textbook.add(review.Metadata.Textbook, "Yes", "No")
+		attendance.add(review.Metadata.Attendance, "Mandatory", "Not Mandatory")
+		wouldTakeAgain.add(review.Metadata.WouldTakeAgain, "Yes", "No")
+	}
+
+	stats.AvgRating = average(ratings)
+	stats.AvgDifficulty = average(difficulties)
+	stats.RatingHistogram = histogram(ratings, 0.5)
+	stats.DifficultyHistogram = histogram(difficulties, 1)
+
+	stats.GradeDistribution = grades.counts(grades.total)
+	sort.SliceStable(stats.GradeDistribution, func(i, j int) bool {
+		return gradeRank(stats.GradeDistribution[i].Label) < gradeRank(stats.GradeDistribution[j].Label)
+	})
+
+	stats.TopTags = tags.counts(len(reviews))
+	if topTags > 0 && len(stats.TopTags) > topTags {
+		stats.TopTags = stats.TopTags[:topTags]
+	}
+
+	stats.TextbookRate = textbook.rate()
+	stats.AttendanceMandatoryRate = attendance.rate()
+	stats.WouldTakeAgainRate = wouldTakeAgain.rate()
+
+	return stats
+}
+
+// labelCounter counts labels case-insensitively, displaying each label the way
+// it was first written without underscores.
+type labelCounter struct {
+	count   map[string]int
+	display map[string]string
+	total   int
+}
+
+func newLabelCounter() *labelCounter {
+	return &labelCounter{count: make(map[string]int), display: make(map[string]string)}
+}
+
+func (c *labelCounter) add(label string) {
+	key := normalizeLabel(label)
+	if key == "" {
+		return
+	}
+	if _, exists := c.display[key]; !exists || strings.Contains(c.display[key], "_") {
+		c.display[key] = strings.TrimSpace(label)
+	}
+	c.count[key]++
+	c.total++
+}
+
+// counts returns the labels most frequent first, with percentages of total.
+func (c *labelCounter) counts(total int) []model.LabelCount {
+	result := make([]model.LabelCount, 0, len(c.count))
+	for key, count := range c.count {
+		result = append(result, model.LabelCount{
+			Label:   strings.ReplaceAll(c.display[key], "_", " "),
+			Count:   count,
+			Percent: round2(float64(count) / float64(max(total, 1)) * 100),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Label < result[j].Label
+	})
+	return result
+}
+
+// yesNoCounter counts answers to a yes or no question, ignoring other answers
+// such as "N/A".
+type yesNoCounter struct {
+	yes, no int
+}
+
+func (c *yesNoCounter) add(answer, yes, no string) {
+	switch {
+	case strings.EqualFold(answer, yes):
+		c.yes++
+	case strings.EqualFold(answer, no):
+		c.no++
+	}
+}
+
+func (c yesNoCounter) rate() model.RateStat {
+	answered := c.yes + c.no
+	if answered == 0 {
+		return model.RateStat{}
+	}
+	return model.RateStat{Rate: round2(float64(c.yes) / float64(answered)), Answered: answered}
+}
+
+// histogram counts values in buckets of the given step from 1 to 5.
+func histogram(values []float64, step float64) []model.HistogramBucket {
+	buckets := []model.HistogramBucket{}
+	for value := 1.0; value <= 5; value += step {
+		buckets = append(buckets, model.HistogramBucket{Value: value})
+	}
+	for _, value := range values {
+		i := int(math.Round((value - 1) / step))
+		if i >= 0 && i < len(buckets) {
+			buckets[i].Count++
+		}
+	}
+	return buckets
+}
+
+func gradeRank(grade string) int {
+	for i, g := range gradeOrder {
+		if strings.EqualFold(g, grade) {
+			return i
+		}
+	}
+	return len(gradeOrder)
+}
+
+func parseScore(value string) (float64, bool) {
+	score := parseNumber(value)
+	return score, score > 0
+}
+
+func average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	return round2(lo.Sum(values) / float64(len(values)))
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
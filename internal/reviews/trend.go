@@ -0,0 +1,114 @@
+package reviews
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/brianrahadi/sfucourses-api/internal/utils"
+)
+
+// Trend periods accepted by BuildTrend.
+const (
+	PeriodTerm = "term"
+	PeriodYear = "year"
+)
+
+// DefaultTrendWindow is the number of periods averaged by BuildTrend by default.
+const DefaultTrendWindow = 3
+
+// BuildTrend groups reviews by term or year, from the earliest to the latest
+// reviewed period. Periods without reviews are kept so that gaps show up. The
+// rolling averages cover the last window periods and weigh every review equally.
+// Reviews without a parseable date are left out.
+func BuildTrend(reviews []model.Review, period string, window int) []model.TrendPoint {
+	type bucket struct {
+		reviews               int
+		ratings, difficulties []float64
+	}
+
+	buckets := make(map[int]*bucket)
+	first, last := 0, 0
+	for _, review := range reviews {
+		date, ok := reviewDate(review)
+		if !ok {
+			continue
+		}
+
+		index := periodIndex(date, period)
+		if len(buckets) == 0 || index < first {
+			first = index
+		}
+		if len(buckets) == 0 || index > last {
+			last = index
+		}
+
+		b, exists := buckets[index]
+		if !exists {
+			b = &bucket{}
+			buckets[index] = b
+		}
+		b.reviews++
+		if rating, ok := parseScore(review.Rating); ok {
+			b.ratings = append(b.ratings, rating)
+		}
+		if difficulty, ok := parseScore(review.Difficulty); ok {
+			b.difficulties = append(b.difficulties, difficulty)
+		}
+	}
+
+	points := []model.TrendPoint{}
+	if len(buckets) == 0 {
+		return points
+	}
+
+	for index := first; index <= last; index++ {
+		point := model.TrendPoint{Period: periodLabel(index, period)}
+
+		var windowRatings, windowDifficulties []float64
+		for i := max(first, index-window+1); i <= index; i++ {
+			if b, exists := buckets[i]; exists {
+				windowRatings = append(windowRatings, b.ratings...)
+				windowDifficulties = append(windowDifficulties, b.difficulties...)
+			}
+		}
+
+		if b, exists := buckets[index]; exists {
+			point.Reviews = b.reviews
+			point.AvgRating = optionalAverage(b.ratings)
+			point.AvgDifficulty = optionalAverage(b.difficulties)
+		}
+		point.RollingRating = optionalAverage(windowRatings)
+		point.RollingDifficulty = optionalAverage(windowDifficulties)
+
+		points = append(points, point)
+	}
+
+	return points
+}
+
+// periodIndex numbers periods consecutively so that gaps can be filled.
+func periodIndex(date time.Time, period string) int {
+	if period == PeriodYear {
+		return date.Year()
+	}
+	return utils.TermSortKey(utils.TermCodeForDate(date))
+}
+
+// periodLabel formats a period index as a year or a term code such as "2024-fall".
+func periodLabel(index int, period string) string {
+	if period == PeriodYear {
+		return fmt.Sprint(index)
+	}
+	return utils.TermCodeFromSortKey(index)
+}
+
+// optionalAverage returns nil when there is nothing to average, so that periods
+// without ratings aren't mistaken for a rating of 0.
+func optionalAverage(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	avg := average(values)
+	return &avg
+}
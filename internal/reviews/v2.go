@@ -0,0 +1,152 @@
+package reviews
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/samber/lo"
+)
+
+// grades lists the grade answers offered by RateMyProfessors, which the raw
+// files sometimes write with underscores or in a different case.
+var grades = slices.Concat(gradeOrder, []string{"Not sure yet", "Rather not say", "Incomplete", "Drop/Withdrawal", "Audit/No Grade"})
+
+// InstructorV2 converts the reviews of an instructor to typed values.
+func InstructorV2(data model.InstructorReviewData) model.InstructorReviewDataV2 {
+	return model.InstructorReviewDataV2{
+		InstructorID:    data.InstructorID,
+		ProfessorID:     data.ProfessorID,
+		ProfessorName:   data.ProfessorName,
+		OverallRating:   ParseFloat(data.OverallRating),
+		WouldTakeAgain:  ParseFloat(data.WouldTakeAgain),
+		DifficultyLevel: ParseFloat(data.DifficultyLevel),
+		Department:      data.Department,
+		TotalRatings:    ParseInt(data.TotalRatings),
+		Keywords:        data.Keywords,
+		Reviews:         ReviewsV2(data.Reviews),
+		Page:            data.Page,
+	}
+}
+
+// CourseV2 converts the reviews of a course to typed values.
+func CourseV2(data model.CourseReviewData) model.CourseReviewDataV2 {
+	return model.CourseReviewDataV2{
+		CourseCode:    data.CourseCode,
+		TotalReviews:  data.TotalReviews,
+		AvgRating:     lo.EmptyableToPtr(data.AvgRating),
+		AvgDifficulty: lo.EmptyableToPtr(data.AvgDifficulty),
+		Keywords:      data.Keywords,
+		Instructors: lo.Map(data.Instructors, func(instructor model.InstructorSummary, _ int) model.InstructorSummaryV2 {
+			return model.InstructorSummaryV2{
+				InstructorID:   instructor.InstructorID,
+				ProfessorID:    instructor.ProfessorID,
+				ProfessorName:  instructor.ProfessorName,
+				Department:     instructor.Department,
+				AvgRating:      lo.EmptyableToPtr(instructor.AvgRating),
+				AvgDifficulty:  lo.EmptyableToPtr(instructor.AvgDifficulty),
+				ReviewCount:    instructor.ReviewCount,
+				WouldTakeAgain: ParseFloat(instructor.WouldTakeAgain),
+				Reviews:        ReviewsV2(instructor.Reviews),
+				Page:           instructor.Page,
+			}
+		}),
+	}
+}
+
+// ProfessorSummariesV2 converts instructor review summaries to typed values.
+func ProfessorSummariesV2(summaries []model.ProfessorSummary) []model.ProfessorSummaryV2 {
+	return lo.Map(summaries, func(summary model.ProfessorSummary, _ int) model.ProfessorSummaryV2 {
+		return model.ProfessorSummaryV2{
+			InstructorID:   summary.InstructorID,
+			URL:            summary.URL,
+			Name:           summary.Name,
+			Department:     summary.Department,
+			Quality:        ParseFloat(summary.Quality),
+			Ratings:        lo.FromPtr(ParseInt(summary.Ratings)),
+			WouldTakeAgain: ParseFloat(summary.WouldTakeAgain),
+			Difficulty:     ParseFloat(summary.Difficulty),
+		}
+	})
+}
+
+// ReviewsV2 converts individual reviews to typed values.
+func ReviewsV2(reviews []model.Review) []model.ReviewV2 {
+	return lo.Map(reviews, func(review model.Review, _ int) model.ReviewV2 {
+		v2 := model.ReviewV2{
+			Rating:              parseOptionalScore(review.Rating),
+			Difficulty:          parseOptionalScore(review.Difficulty),
+			CourseCode:          review.CourseCode,
+			ForCredit:           parseYesNo(review.Metadata.ForCredit, "Yes", "No"),
+			AttendanceMandatory: parseYesNo(review.Metadata.Attendance, "Mandatory", "Not Mandatory"),
+			WouldTakeAgain:      parseYesNo(review.Metadata.WouldTakeAgain, "Yes", "No"),
+			Textbook:            parseYesNo(review.Metadata.Textbook, "Yes", "No"),
+			OnlineClass:         strings.EqualFold(review.Metadata.OnlineClass, "Yes"),
+			Grade:               canonicalGrade(review.Metadata.Grade),
+			ReviewMsg:           review.ReviewMsg,
+			Helpful:             lo.FromPtr(ParseInt(review.Helpful)),
+			NotHelpful:          lo.FromPtr(ParseInt(review.NotHelpful)),
+			Tags: lo.Map(review.Tags, func(tag string, _ int) string {
+				return strings.TrimSpace(tag)
+			}),
+		}
+		if date, ok := reviewDate(review); ok {
+			v2.Date = lo.ToPtr(date.Format(DateLayout))
+		}
+		return v2
+	})
+}
+
+// ParseFloat parses a number such as "4.2" or a percentage such as "85%".
+// Returns nil for missing values such as "", "N/A" or "-".
+func ParseFloat(value string) *float64 {
+	number, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "%"), 64)
+	if err != nil {
+		return nil
+	}
+	return &number
+}
+
+// ParseInt parses a count such as "13". Returns nil for missing values.
+func ParseInt(value string) *int {
+	number := ParseFloat(value)
+	if number == nil {
+		return nil
+	}
+	return lo.ToPtr(int(*number))
+}
+
+// parseOptionalScore parses a rating from 1 to 5, where 0 means unrated.
+func parseOptionalScore(value string) *float64 {
+	if score, ok := parseScore(value); ok {
+		return &score
+	}
+	return nil
+}
+
+// parseYesNo returns nil unless the answer is one of yes or no.
+func parseYesNo(answer, yes, no string) *bool {
+	switch {
+	case strings.EqualFold(answer, yes):
+		return lo.ToPtr(true)
+	case strings.EqualFold(answer, no):
+		return lo.ToPtr(false)
+	}
+	return nil
+}
+
+// canonicalGrade writes a grade the way RateMyProfessors displays it.
+// Example: canonicalGrade("Not_Sure_Yet") returns "Not sure yet"
+func canonicalGrade(grade string) *string {
+	key := normalizeLabel(grade)
+	if key == "" || key == "n/a" {
+		return nil
+	}
+	if canonical, found := lo.Find(grades, func(g string) bool {
+		return normalizeLabel(g) == key
+	}); found {
+		return &canonical
+	}
+	return lo.ToPtr(strings.TrimSpace(strings.ReplaceAll(grade, "_", " ")))
+}
@@ -0,0 +1,325 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/brianrahadi/sfucourses-api/internal/utils"
+)
+
+// field is a searchable field of a course outline.
+type field int
+
+const (
+	fieldCode field = iota
+	fieldTitle
+	fieldDesignation
+	fieldDescription
+	fieldNotes
+	numFields
+)
+
+var fieldNames = [numFields]string{"code", "title", "designation", "description", "notes"}
+
+// fieldBoosts weighs matches by field, so that a course about a topic ranks
+// above a course that mentions it in passing.
+var fieldBoosts = [numFields]float64{4, 3, 1.5, 1, 0.5}
+
+// BM25 parameters
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// stopwords aren't indexed, but still count as positions so that phrases
+// such as "history of science" only match the words in that order.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "in": true, "into": true, "is": true, "it": true, "of": true, "on": true,
+	"or": true, "such": true, "that": true, "the": true, "their": true, "this": true, "to": true,
+	"with": true,
+}
+
+// posting lists the positions of a term in one field of one outline.
+type posting struct {
+	doc       int
+	field     field
+	positions []int
+}
+
+// Index is an inverted index over the title, description, notes and
+// designation of course outlines, and their course codes.
+type Index struct {
+	outlines  []model.CourseOutline
+	postings  map[string][]posting
+	lengths   [][numFields]int
+	avgLength [numFields]float64
+}
+
+// Query is a search request. Text holds words, which must all match, and
+// quoted phrases, which must match in order.
+type Query struct {
+	Text  string
+	Dept  string // department code, such as "CMPT"
+	Level int    // course level, such as 300 for 3XX courses
+	Term  string // term code of an offering, such as "2024-fall"
+}
+
+// NewIndex indexes course outlines.
+func NewIndex(outlines []model.CourseOutline) *Index {
+	idx := &Index{
+		outlines: outlines,
+		postings: make(map[string][]posting),
+		lengths:  make([][numFields]int, len(outlines)),
+	}
+
+	var totals [numFields]int
+	for doc, outline := range outlines {
+		texts := [numFields]string{
+			outline.Dept + " " + outline.Number + " " + outline.Dept + outline.Number,
+			outline.Title,
+			outline.Designation,
+			outline.Description,
+			outline.Notes,
+		}
+		for f, text := range texts {
+			positions := make(map[string][]int)
+			tokens := tokenize(text)
+			for pos, token := range tokens {
+				if token != "" {
+					positions[token] = append(positions[token], pos)
+				}
+			}
+			for term, termPositions := range positions {
+				idx.postings[term] = append(idx.postings[term], posting{doc: doc, field: field(f), positions: termPositions})
+			}
+			idx.lengths[doc][f] = len(tokens)
+			totals[f] += len(tokens)
+		}
+	}
+
+	if len(outlines) > 0 {
+		for f := range totals {
+			idx.avgLength[f] = float64(totals[f]) / float64(len(outlines))
+		}
+	}
+	return idx
+}
+
+// clause is a word or phrase of a query, as stemmed terms and their offsets.
+type clause struct {
+	terms   []string
+	offsets []int
+}
+
+// Search returns the outlines matching every word and phrase of a query that
+// pass its filters, ranked by BM25 with field boosts.
+func (idx *Index) Search(query Query) []model.CourseSearchResult {
+	clauses := parseQuery(query.Text)
+	if len(clauses) == 0 {
+		return []model.CourseSearchResult{}
+	}
+
+	// matches maps outlines to the number of matches of each field, by clause
+	matches := make([]map[int]*[numFields]int, len(clauses))
+	for i, c := range clauses {
+		matches[i] = idx.match(c)
+	}
+
+	scores := make(map[int]float64)
+	fields := make(map[int]*[numFields]bool)
+outlines:
+	for doc := range matches[0] {
+		if !idx.passes(doc, query) {
+			continue
+		}
+		for i := range clauses {
+			if _, found := matches[i][doc]; !found {
+				continue outlines
+			}
+		}
+
+		fields[doc] = &[numFields]bool{}
+		for i := range clauses {
+			scores[doc] += idx.score(doc, matches[i][doc], len(matches[i]), fields[doc])
+		}
+	}
+
+	results := make([]model.CourseSearchResult, 0, len(scores))
+	for doc, score := range scores {
+		outline := idx.outlines[doc]
+		result := model.CourseSearchResult{
+			ShortCourseOutline: model.ShortCourseOutline{
+				Dept:   outline.Dept,
+				Number: outline.Number,
+				Title:  outline.Title,
+				Units:  outline.Units,
+			},
+			Score:         math.Round(score*1000) / 1000,
+			MatchedFields: []string{},
+		}
+		for f, found := range fields[doc] {
+			if found {
+				result.MatchedFields = append(result.MatchedFields, fieldNames[f])
+			}
+		}
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Dept != results[j].Dept {
+			return results[i].Dept < results[j].Dept
+		}
+		return results[i].Number < results[j].Number
+	})
+	return results
+}
+
+// match returns the number of matches of a clause in every field of every
+// outline containing it.
+func (idx *Index) match(c clause) map[int]*[numFields]int {
+	matches := make(map[int]*[numFields]int)
+
+	if len(c.terms) == 1 {
+		for _, p := range idx.postings[c.terms[0]] {
+			counts := matches[p.doc]
+			if counts == nil {
+				counts = &[numFields]int{}
+				matches[p.doc] = counts
+			}
+			counts[p.field] = len(p.positions)
+		}
+		return matches
+	}
+
+	// Positions of every term by outline and field, to check phrase order
+	positions := make([]map[[2]int][]int, len(c.terms))
+	for i, term := range c.terms {
+		positions[i] = make(map[[2]int][]int)
+		for _, p := range idx.postings[term] {
+			positions[i][[2]int{p.doc, int(p.field)}] = p.positions
+		}
+	}
+
+	for key, starts := range positions[0] {
+		count := 0
+		for _, start := range starts {
+			inOrder := true
+			for i := 1; i < len(c.terms); i++ {
+				termPositions := positions[i][key]
+				at := sort.SearchInts(termPositions, start+c.offsets[i])
+				if at == len(termPositions) || termPositions[at] != start+c.offsets[i] {
+					inOrder = false
+					break
+				}
+			}
+			if inOrder {
+				count++
+			}
+		}
+		if count == 0 {
+			continue
+		}
+
+		counts := matches[key[0]]
+		if counts == nil {
+			counts = &[numFields]int{}
+			matches[key[0]] = counts
+		}
+		counts[key[1]] = count
+	}
+	return matches
+}
+
+// score returns the BM25 score of a clause in an outline, where docFreq is
+// the number of outlines containing the clause, marking the matched fields.
+func (idx *Index) score(doc int, counts *[numFields]int, docFreq int, matched *[numFields]bool) float64 {
+	n := float64(len(idx.outlines))
+	idf := math.Log(1 + (n-float64(docFreq)+0.5)/(float64(docFreq)+0.5))
+
+	score := 0.0
+	for f, count := range counts {
+		if count == 0 {
+			continue
+		}
+		matched[f] = true
+		tf := float64(count)
+		norm := 1 - b + b*float64(idx.lengths[doc][f])/idx.avgLength[f]
+		score += fieldBoosts[f] * idf * tf * (k1 + 1) / (tf + k1*norm)
+	}
+	return score
+}
+
+// passes reports whether an outline passes the filters of a query.
+func (idx *Index) passes(doc int, query Query) bool {
+	outline := idx.outlines[doc]
+	if query.Dept != "" && !strings.EqualFold(outline.Dept, query.Dept) {
+		return false
+	}
+	if query.Level != 0 && (outline.Number == "" || int(outline.Number[0]-'0') != query.Level/100) {
+		return false
+	}
+	if query.Term != "" {
+		for _, offering := range outline.Offerings {
+			if strings.EqualFold(utils.TermCodeFromTerm(offering.Term), query.Term) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// parseQuery splits query text into quoted phrases and single words.
+func parseQuery(text string) []clause {
+	var clauses []clause
+	for i, part := range strings.Split(text, `"`) {
+		// Odd parts are inside quotes
+		if i%2 == 1 {
+			c := clause{}
+			for pos, token := range tokenize(part) {
+				if token != "" {
+					c.terms = append(c.terms, token)
+					c.offsets = append(c.offsets, pos)
+				}
+			}
+			if len(c.terms) > 0 {
+				base := c.offsets[0]
+				for j := range c.offsets {
+					c.offsets[j] -= base
+				}
+				clauses = append(clauses, c)
+			}
+			continue
+		}
+
+		for _, token := range tokenize(part) {
+			if token != "" {
+				clauses = append(clauses, clause{terms: []string{token}, offsets: []int{0}})
+			}
+		}
+	}
+	return clauses
+}
+
+// tokenize lowercases and stems the words of a text. Stopwords are returned
+// as empty strings to keep the positions of the other words.
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		if stopwords[word] {
+			words[i] = ""
+			continue
+		}
+		words[i] = Stem(word)
+	}
+	return words
+}
@@ -0,0 +1,69 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/samber/lo"
+)
+
+func TestStem(t *testing.T) {
+	tests := map[string]string{
+		"caresses":    "caress",
+		"ponies":      "poni",
+		"cats":        "cat",
+		"agreed":      "agre",
+		"hopping":     "hop",
+		"filing":      "file",
+		"happy":       "happi",
+		"relational":  "relat",
+		"hopeful":     "hope",
+		"adjustable":  "adjust",
+		"adoption":    "adopt",
+		"probate":     "probat",
+		"controlling": "control",
+		"learning":    "learn",
+		"ethics":      "ethic",
+		"ethical":     "ethic",
+		"225":         "225",
+		"économie":    "économie",
+	}
+	for word, want := range tests {
+		if got := Stem(word); got != want {
+			t.Errorf("Stem(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	idx := NewIndex([]model.CourseOutline{
+		{Dept: "CMPT", Number: "410", Title: "Machine Learning", Description: "Algorithms that learn from data."},
+		{Dept: "CMPT", Number: "225", Title: "Data Structures", Description: "Stacks, queues and search trees.",
+			Offerings: []model.CourseOffering{{Term: "Fall 2024"}}},
+		{Dept: "MATH", Number: "232", Title: "Linear Algebra", Description: "Matrices used in machine learning and data science."},
+		{Dept: "PHIL", Number: "120", Title: "Moral Problems", Description: "The structures of data, learned the hard way."},
+	})
+
+	codes := func(results []model.CourseSearchResult) []string {
+		return lo.Map(results, func(r model.CourseSearchResult, _ int) string { return r.Dept + r.Number })
+	}
+
+	if got := codes(idx.Search(Query{Text: "machine learned"})); len(got) != 2 || got[0] != "CMPT410" {
+		t.Errorf("Search(machine learned) = %v, want CMPT410 first, then MATH232", got)
+	}
+	if got := codes(idx.Search(Query{Text: `"data structures"`})); len(got) != 1 || got[0] != "CMPT225" {
+		t.Errorf("Search(\"data structures\") = %v, want [CMPT225]", got)
+	}
+	if got := codes(idx.Search(Query{Text: "data", Dept: "math"})); len(got) != 1 || got[0] != "MATH232" {
+		t.Errorf("Search(data, dept=math) = %v, want [MATH232]", got)
+	}
+	if got := codes(idx.Search(Query{Text: "data", Level: 200, Term: "2024-fall"})); len(got) != 1 || got[0] != "CMPT225" {
+		t.Errorf("Search(data, level=200, term=2024-fall) = %v, want [CMPT225]", got)
+	}
+	if got := codes(idx.Search(Query{Text: "cmpt 225"})); len(got) != 1 || got[0] != "CMPT225" {
+		t.Errorf("Search(cmpt 225) = %v, want [CMPT225]", got)
+	}
+	if got := idx.Search(Query{Text: "the of"}); len(got) != 0 {
+		t.Errorf("Search(the of) = %v, want no results", codes(got))
+	}
+}
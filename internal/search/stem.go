@@ -0,0 +1,221 @@
+package search
+
+// Stem reduces an English word to its stem with the Porter stemming algorithm,
+// so that "learning", "learned" and "learns" all become "learn". Words that
+// aren't lowercase ASCII letters are returned as is.
+// See https://tartarus.org/martin/PorterStemmer/def.txt
+func Stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+
+	s := &stemmer{b: []byte(word)}
+	s.step1a()
+	s.step1b()
+	s.step1c()
+	s.step2()
+	s.step3()
+	s.step4()
+	s.step5()
+	return string(s.b)
+}
+
+type stemmer struct {
+	b []byte
+	j int // length of the stem before the suffix matched by ends
+}
+
+// cons reports whether b[i] is a consonant. Y is a consonant unless it
+// follows a consonant.
+func (s *stemmer) cons(i int) bool {
+	switch s.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !s.cons(i-1)
+	}
+	return true
+}
+
+// measure returns the number of vowel-consonant sequences in b[:j].
+func (s *stemmer) measure(j int) int {
+	n, i := 0, 0
+	for i < j && s.cons(i) {
+		i++
+	}
+	for i < j {
+		for i < j && !s.cons(i) {
+			i++
+		}
+		if i >= j {
+			break
+		}
+		for i < j && s.cons(i) {
+			i++
+		}
+		n++
+	}
+	return n
+}
+
+// vowelInStem reports whether b[:j] contains a vowel.
+func (s *stemmer) vowelInStem(j int) bool {
+	for i := 0; i < j; i++ {
+		if !s.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doubleCons reports whether b[i-1:i+1] is a double consonant.
+func (s *stemmer) doubleCons(i int) bool {
+	return i >= 1 && s.b[i] == s.b[i-1] && s.cons(i)
+}
+
+// cvc reports whether b[i-2:i+1] is consonant-vowel-consonant and the last
+// consonant isn't w, x or y, as in "hop" but not "snow".
+func (s *stemmer) cvc(i int) bool {
+	if i < 2 || !s.cons(i) || s.cons(i-1) || !s.cons(i-2) {
+		return false
+	}
+	switch s.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
+
+// ends reports whether the word ends with suffix, setting j to the length of
+// the rest of the word.
+func (s *stemmer) ends(suffix string) bool {
+	if len(suffix) > len(s.b) || string(s.b[len(s.b)-len(suffix):]) != suffix {
+		return false
+	}
+	s.j = len(s.b) - len(suffix)
+	return true
+}
+
+// setTo replaces the suffix matched by ends.
+func (s *stemmer) setTo(replacement string) {
+	s.b = append(s.b[:s.j], replacement...)
+}
+
+// replace replaces the first of the suffixes the word ends with, if the rest
+// of the word has a measure of at least minMeasure.
+func (s *stemmer) replace(suffixes [][2]string, minMeasure int) {
+	for _, suffix := range suffixes {
+		if s.ends(suffix[0]) {
+			if s.measure(s.j) >= minMeasure {
+				s.setTo(suffix[1])
+			}
+			return
+		}
+	}
+}
+
+// step1a removes plurals: caresses → caress, ponies → poni, cats → cat.
+func (s *stemmer) step1a() {
+	switch {
+	case s.ends("sses"):
+		s.setTo("ss")
+	case s.ends("ies"):
+		s.setTo("i")
+	case s.ends("ss"):
+	case s.ends("s"):
+		s.setTo("")
+	}
+}
+
+// step1b removes -ed and -ing: agreed → agree, hopping → hop, filing → file.
+func (s *stemmer) step1b() {
+	if s.ends("eed") {
+		if s.measure(s.j) > 0 {
+			s.setTo("ee")
+		}
+		return
+	}
+
+	if !(s.ends("ed") || s.ends("ing")) || !s.vowelInStem(s.j) {
+		return
+	}
+	s.setTo("")
+
+	last := len(s.b) - 1
+	switch {
+	case s.ends("at"):
+		s.setTo("ate")
+	case s.ends("bl"):
+		s.setTo("ble")
+	case s.ends("iz"):
+		s.setTo("ize")
+	case s.doubleCons(last) && s.b[last] != 'l' && s.b[last] != 's' && s.b[last] != 'z':
+		s.b = s.b[:last]
+	case s.measure(len(s.b)) == 1 && s.cvc(last):
+		s.b = append(s.b, 'e')
+	}
+}
+
+// step1c turns a final y into i after a vowel: happy → happi.
+func (s *stemmer) step1c() {
+	if s.ends("y") && s.vowelInStem(s.j) {
+		s.b[len(s.b)-1] = 'i'
+	}
+}
+
+// step2 maps double suffixes to single ones: relational → relate.
+func (s *stemmer) step2() {
+	s.replace([][2]string{
+		{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"},
+		{"izer", "ize"}, {"bli", "ble"}, {"alli", "al"}, {"entli", "ent"}, {"eli", "e"},
+		{"ousli", "ous"}, {"ization", "ize"}, {"ation", "ate"}, {"ator", "ate"},
+		{"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"}, {"ousness", "ous"},
+		{"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"}, {"logi", "log"},
+	}, 1)
+}
+
+// step3 removes -ful, -ness and similar suffixes: hopeful → hope.
+func (s *stemmer) step3() {
+	s.replace([][2]string{
+		{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"},
+		{"ical", "ic"}, {"ful", ""}, {"ness", ""},
+	}, 1)
+}
+
+// step4 removes -ant, -ence and similar suffixes: adjustable → adjust.
+func (s *stemmer) step4() {
+	for _, suffix := range []string{
+		"ement", "ment", "ent", "ance", "ence", "able", "ible", "ant", "ism",
+		"ate", "iti", "ous", "ive", "ize", "ion", "al", "er", "ic", "ou",
+	} {
+		if !s.ends(suffix) {
+			continue
+		}
+		if suffix == "ion" && (s.j == 0 || (s.b[s.j-1] != 's' && s.b[s.j-1] != 't')) {
+			return
+		}
+		if s.measure(s.j) > 1 {
+			s.setTo("")
+		}
+		return
+	}
+}
+
+// step5 removes a final e and a double l: probate → probat, controll → control.
+func (s *stemmer) step5() {
+	if s.ends("e") {
+		if m := s.measure(s.j); m > 1 || (m == 1 && !s.cvc(s.j-1)) {
+			s.setTo("")
+		}
+	}
+
+	last := len(s.b) - 1
+	if s.b[last] == 'l' && s.doubleCons(last) && s.measure(len(s.b)) > 1 {
+		s.b = s.b[:last]
+	}
+}
@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/brianrahadi/sfucourses-api/internal/identity"
+	. "github.com/brianrahadi/sfucourses-api/internal/model"
+)
+
+type IdentityStore struct {
+	resolver   *identity.Resolver
+	lastLoaded time.Time
+	mu         sync.RWMutex
+	filePath   string
+}
+
+func NewIdentityStore() (*IdentityStore, error) {
+	store := &IdentityStore{
+		filePath: "./internal/store/json/instructor_aliases.json",
+	}
+
+	if err := store.loadIdentities(); err != nil {
+		return nil, fmt.Errorf("error loading instructor identities: %v", err)
+	}
+
+	return store, nil
+}
+
+func (s *IdentityStore) ForceReload() error {
+	return s.loadIdentities()
+}
+
+func (s *IdentityStore) loadIdentities() (err error) {
+	defer observeReload("identities", time.Now(), &err)
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		return fmt.Errorf("error reading file %s: %v", s.filePath, err)
+	}
+
+	var identities []InstructorIdentity
+	if err := json.Unmarshal(data, &identities); err != nil {
+		return fmt.Errorf("error parsing JSON: %v", err)
+	}
+
+	resolver := identity.NewResolver(identities)
+
+	s.mu.Lock()
+	s.resolver = resolver
+	s.lastLoaded = time.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *IdentityStore) reloadIfNeeded() error {
+	s.mu.RLock()
+	shouldReload := time.Since(s.lastLoaded) > time.Hour
+	s.mu.RUnlock()
+
+	if shouldReload {
+		if err := s.loadIdentities(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LastLoaded returns when the identities were last loaded.
+func (s *IdentityStore) LastLoaded() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastLoaded
+}
+
+func (s *IdentityStore) getResolver() *identity.Resolver {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.resolver
+}
+
+// Lookup returns the identity matching an instructor ID, email or unambiguous name.
+func (s *IdentityStore) Lookup(ctx context.Context, query string) (InstructorIdentity, error) {
+	if err := s.reloadIfNeeded(); err != nil {
+		return InstructorIdentity{}, err
+	}
+
+	identity, ok := s.getResolver().Lookup(query)
+	if !ok {
+		return InstructorIdentity{}, ErrNotFound
+	}
+	return identity, nil
+}
+
+// Resolve returns the ID of an instructor by email, or by name if the name is
+// unambiguous. Returns an empty string if the instructor can't be resolved.
+func (s *IdentityStore) Resolve(name, email string) string {
+	return s.getResolver().Resolve(name, email)
+}
+
+// ResolveInDepts returns the ID of an instructor by name, using the department
+// codes the name was seen with to tell namesakes apart.
+func (s *IdentityStore) ResolveInDepts(name string, depts []string) string {
+	return s.getResolver().ResolveInDepts(name, depts)
+}
+
+// ReviewFileID returns the ID of the instructor a review file belongs to. The
+// file path is relative to the instructor_reviews directory.
+func (s *IdentityStore) ReviewFileID(file string) string {
+	return s.getResolver().ReviewFileID(file)
+}
@@ -4,18 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/brianrahadi/sfucourses-api/internal/identity"
 	. "github.com/brianrahadi/sfucourses-api/internal/model"
 	"github.com/samber/lo"
 )
 
 type InstructorStore struct {
 	cachedInstructors []InstructorResponse
+	nameIndex         *identity.NameIndex
 	lastLoaded        time.Time
 	mu                sync.RWMutex
 	filePath          string
@@ -37,7 +40,9 @@ func (s *InstructorStore) ForceReload() error {
 	return s.loadInstructors()
 }
 
-func (s *InstructorStore) loadInstructors() error {
+func (s *InstructorStore) loadInstructors() (err error) {
+	defer observeReload("instructors", time.Now(), &err)
+
 	data, err := os.ReadFile(s.filePath)
 	if err != nil {
 		return fmt.Errorf("error reading file %s: %v", s.filePath, err)
@@ -53,8 +58,17 @@ func (s *InstructorStore) loadInstructors() error {
 		return strings.ToLower(instructors[i].Name) < strings.ToLower(instructors[j].Name)
 	})
 
+	nameIndex := identity.NewNameIndex()
+	for i, instructor := range instructors {
+		nameIndex.Add(i, instructor.Name)
+		for _, alias := range instructor.Aliases {
+			nameIndex.Add(i, alias)
+		}
+	}
+
 	s.mu.Lock()
 	s.cachedInstructors = instructors
+	s.nameIndex = nameIndex
 	s.lastLoaded = time.Now()
 	s.mu.Unlock()
 
@@ -74,6 +88,13 @@ func (s *InstructorStore) reloadIfNeeded() error {
 	return nil
 }
 
+// LastLoaded returns when the instructors were last loaded.
+func (s *InstructorStore) LastLoaded() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastLoaded
+}
+
 func (s *InstructorStore) Get(ctx context.Context, dept, number, name string) ([]InstructorResponse, error) {
 	if err := s.reloadIfNeeded(); err != nil {
 		return nil, err
@@ -90,8 +111,12 @@ func (s *InstructorStore) Get(ctx context.Context, dept, number, name string) ([
 	number = strings.ToUpper(number)
 
 	if name != "" {
+		name = strings.ToLower(name)
 		instructors := lo.Filter(s.cachedInstructors, func(instructor InstructorResponse, _ int) bool {
-			return strings.Contains(strings.ToLower(instructor.Name), strings.ToLower(name))
+			return strings.Contains(strings.ToLower(instructor.Name), name) ||
+				lo.SomeBy(instructor.Aliases, func(alias string) bool {
+					return strings.Contains(strings.ToLower(alias), name)
+				})
 		})
 		return instructors, nil
 	}
@@ -116,3 +141,28 @@ func (s *InstructorStore) Get(ctx context.Context, dept, number, name string) ([
 
 	return nil, ErrNotFound
 }
+
+// Search returns the instructors whose name or alias fuzzily matches a query,
+// best match first. Matching ignores case, accents and word order, and tolerates
+// small typos. Returns all matches if limit is zero or less.
+func (s *InstructorStore) Search(ctx context.Context, query string, limit int) ([]InstructorSearchResult, error) {
+	if err := s.reloadIfNeeded(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	results := lo.Map(s.nameIndex.Search(query, limit), func(match identity.SearchMatch, _ int) InstructorSearchResult {
+		instructor := s.cachedInstructors[match.Ref]
+		return InstructorSearchResult{
+			ID:          instructor.ID,
+			Name:        instructor.Name,
+			Aliases:     instructor.Aliases,
+			MatchedName: match.Name,
+			Score:       math.Round(match.Score*1000) / 1000,
+			Offerings:   instructor.Offerings,
+		}
+	})
+	return results, nil
+}
@@ -11,12 +11,14 @@ import (
 
 	. "github.com/brianrahadi/sfucourses-api/internal/model"
 	"github.com/brianrahadi/sfucourses-api/internal/prereq"
+	"github.com/brianrahadi/sfucourses-api/internal/search"
 	"github.com/samber/lo"
 )
 
 type OutlineStore struct {
 	cachedOutlines []CourseOutline
 	parsedPrereqs  PrereqMap
+	searchIndex    *search.Index
 	lastLoaded     time.Time
 	mu             sync.RWMutex
 	filePath       string
@@ -38,7 +40,9 @@ func (s *OutlineStore) ForceReload() error {
 	return s.loadOutlines()
 }
 
-func (s *OutlineStore) loadOutlines() error {
+func (s *OutlineStore) loadOutlines() (err error) {
+	defer observeReload("outlines", time.Now(), &err)
+
 	data, err := os.ReadFile(s.filePath)
 	if err != nil {
 		return fmt.Errorf("error reading file %s: %v", s.filePath, err)
@@ -52,6 +56,7 @@ func (s *OutlineStore) loadOutlines() error {
 	s.mu.Lock()
 	s.cachedOutlines = outlines
 	s.parsedPrereqs = prereq.ParseAll(outlines)
+	s.searchIndex = search.NewIndex(outlines)
 	s.lastLoaded = time.Now()
 	s.mu.Unlock()
 
@@ -71,6 +76,13 @@ func (s *OutlineStore) reloadIfNeeded() error {
 	return nil
 }
 
+// LastLoaded returns when the outlines were last loaded.
+func (s *OutlineStore) LastLoaded() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastLoaded
+}
+
 func (s *OutlineStore) Get(ctx context.Context, dept, number string) ([]CourseOutline, error) {
 	if err := s.reloadIfNeeded(); err != nil {
 		return nil, err
@@ -103,6 +115,17 @@ func (s *OutlineStore) Get(ctx context.Context, dept, number string) ([]CourseOu
 	return nil, ErrNotFound
 }
 
+// Search returns the outlines matching a full-text query, most relevant first.
+func (s *OutlineStore) Search(ctx context.Context, query search.Query) ([]CourseSearchResult, error) {
+	if err := s.reloadIfNeeded(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.searchIndex.Search(query), nil
+}
+
 func (s *OutlineStore) GetPrereqMap() PrereqMap {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -0,0 +1,565 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/brianrahadi/sfucourses-api/internal/reviews"
+	"github.com/samber/lo"
+)
+
+var courseDeptPattern = regexp.MustCompile(`^[A-Za-z]+`)
+
+type ReviewStore struct {
+	instructorReviews   map[string]InstructorReviewData // keyed by file path relative to instructorDir
+	instructorFiles     map[string]string               // lowercase file name without extension to relative path
+	courseReviews       map[string]CourseReviewData     // keyed by uppercase course code
+	instructorRatings   map[string]InstructorRatings    // keyed by instructor ID
+	instructorSummaries []ProfessorSummary
+	courseSummaries     []CourseSummary
+	quarantine          []QuarantinedCourseCode
+	identities          instructorResolver
+	outlines            outlineLister
+	lastLoaded          time.Time
+	mu                  sync.RWMutex
+	instructorDir       string
+	courseDir           string
+	instructorsFile     string
+	keywordsFile        string
+}
+
+func NewReviewStore(identities instructorResolver, outlines outlineLister) (*ReviewStore, error) {
+	store := &ReviewStore{
+		identities:      identities,
+		outlines:        outlines,
+		instructorDir:   "./internal/store/json/instructor_reviews",
+		courseDir:       "./internal/store/json/course_reviews",
+		instructorsFile: "./internal/store/json/all_instructor_reviews.json",
+		keywordsFile:    "./internal/store/json/review_keywords.json",
+	}
+
+	if err := store.loadReviews(); err != nil {
+		return nil, fmt.Errorf("error loading reviews: %v", err)
+	}
+
+	return store, nil
+}
+
+func (s *ReviewStore) ForceReload() error {
+	return s.loadReviews()
+}
+
+func (s *ReviewStore) loadReviews() (err error) {
+	defer observeReload("reviews", time.Now(), &err)
+
+	instructorReviews, instructorFiles, err := s.loadInstructorReviews()
+	if err != nil {
+		return err
+	}
+
+	courseFiles, err := s.loadCourseReviews()
+	if err != nil {
+		return err
+	}
+
+	var instructorSummaries []ProfessorSummary
+	if err := readJSONFile(s.instructorsFile, &instructorSummaries); err != nil {
+		return err
+	}
+
+	s.resolveInstructorIDs(instructorReviews, courseFiles, instructorSummaries)
+
+	var quarantine []QuarantinedCourseCode
+	if s.outlines != nil {
+		outlines, err := s.outlines.Get(context.Background(), "", "")
+		if err != nil {
+			return err
+		}
+		courseFiles, quarantine = resolveCourseCodes(reviews.NewCatalog(outlines), instructorReviews, courseFiles)
+	}
+
+	courseReviews := aggregateCourseReviews(courseFiles)
+	if err := s.loadKeywords(instructorReviews, courseReviews); err != nil {
+		return err
+	}
+	courseSummaries := summarizeCourses(courseReviews)
+	instructorRatings := summarizeInstructors(instructorReviews)
+
+	s.mu.Lock()
+	s.instructorReviews = instructorReviews
+	s.instructorFiles = instructorFiles
+	s.courseReviews = courseReviews
+	s.instructorRatings = instructorRatings
+	s.instructorSummaries = instructorSummaries
+	s.courseSummaries = courseSummaries
+	s.quarantine = quarantine
+	s.lastLoaded = time.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+// loadInstructorReviews reads every instructor review file. Files that can't be
+// parsed are skipped so that one bad scrape doesn't take all reviews down.
+func (s *ReviewStore) loadInstructorReviews() (map[string]InstructorReviewData, map[string]string, error) {
+	departments, err := os.ReadDir(s.instructorDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading directory %s: %v", s.instructorDir, err)
+	}
+
+	instructorReviews := make(map[string]InstructorReviewData)
+	files := make(map[string]string)
+
+	for _, dept := range departments {
+		if !dept.IsDir() {
+			continue
+		}
+
+		entries, err := os.ReadDir(filepath.Join(s.instructorDir, dept.Name()))
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading directory %s: %v", dept.Name(), err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+				continue
+			}
+
+			relativePath := path.Join(dept.Name(), entry.Name())
+			var reviewData InstructorReviewData
+			if err := readJSONFile(filepath.Join(s.instructorDir, dept.Name(), entry.Name()), &reviewData); err != nil {
+				log.Printf("Skipping instructor review file: %v", err)
+				continue
+			}
+
+			reviews.NormalizeDates(reviewData.Reviews)
+			instructorReviews[relativePath] = reviewData
+
+			// Files are visited in directory order, so the first department wins on name clashes
+			name := strings.ToLower(strings.TrimSuffix(entry.Name(), ".json"))
+			if _, exists := files[name]; !exists {
+				files[name] = relativePath
+			}
+		}
+	}
+
+	return instructorReviews, files, nil
+}
+
+// loadCourseReviews reads every course review file, grouping files whose names
+// only differ in case under one course code.
+func (s *ReviewStore) loadCourseReviews() (map[string][]CourseReviewData, error) {
+	entries, err := os.ReadDir(s.courseDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory %s: %v", s.courseDir, err)
+	}
+
+	courseFiles := make(map[string][]CourseReviewData)
+	for _, entry := range entries {
+		// ALL_COURSES.json is a copy of the course summaries, not a course
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") || entry.Name() == "ALL_COURSES.json" {
+			continue
+		}
+
+		var courseData CourseReviewData
+		if err := readJSONFile(filepath.Join(s.courseDir, entry.Name()), &courseData); err != nil {
+			log.Printf("Skipping course review file: %v", err)
+			continue
+		}
+
+		for _, instructor := range courseData.Instructors {
+			reviews.NormalizeDates(instructor.Reviews)
+		}
+
+		code := strings.ToUpper(strings.TrimSuffix(entry.Name(), ".json"))
+		courseFiles[code] = append(courseFiles[code], courseData)
+	}
+
+	return courseFiles, nil
+}
+
+// aggregateCourseReviews merges the files of every course, once instructor IDs
+// are resolved so that instructor entries can be merged by identity.
+func aggregateCourseReviews(courseFiles map[string][]CourseReviewData) map[string]CourseReviewData {
+	courseReviews := make(map[string]CourseReviewData, len(courseFiles))
+	for code, files := range courseFiles {
+		courseReviews[code] = reviews.AggregateCourse(code, files)
+	}
+	return courseReviews
+}
+
+// resolveCourseCodes resolves review course codes against the outline catalog.
+// Instructor reviews keep unresolved codes as entered, while course files are
+// regrouped by resolved course code, so that reviews of "cmpt-225", "CMPT225L"
+// and "225" by a CMPT instructor all end up in CMPT225. Reviews whose codes
+// can't be resolved are quarantined.
+func resolveCourseCodes(catalog *reviews.Catalog, instructorReviews map[string]InstructorReviewData, courseFiles map[string][]CourseReviewData) (map[string][]CourseReviewData, []QuarantinedCourseCode) {
+	// Codes without a department are resolved with the departments the
+	// instructor is reviewed in, by RateMyProfessors professor ID
+	deptHints := make(map[string][]string)
+	for _, reviewData := range instructorReviews {
+		hints := catalog.DeptHints(reviewData.Reviews)
+		catalog.ResolveCourseCodes(reviewData.Reviews, hints)
+		if _, exists := deptHints[reviewData.ProfessorID]; !exists {
+			deptHints[reviewData.ProfessorID] = hints
+		}
+	}
+
+	resolved := make(map[string][]CourseReviewData)
+	quarantined := make(map[string]*QuarantinedCourseCode)
+
+	for fileCode, files := range courseFiles {
+		for _, courseData := range files {
+			for _, instructor := range courseData.Instructors {
+				hints, found := deptHints[instructor.ProfessorID]
+				if !found {
+					hints = catalog.DeptHints(instructor.Reviews)
+				}
+				unresolved := catalog.ResolveCourseCodes(instructor.Reviews, hints)
+
+				byCode := make(map[string][]Review)
+				for i, review := range instructor.Reviews {
+					if reason, found := unresolved[i]; found {
+						code := reviews.NormalizeCourseCode(review.CourseCode)
+						if code == "" {
+							code = fileCode
+						}
+						entry, exists := quarantined[code]
+						if !exists {
+							entry = &QuarantinedCourseCode{CourseCode: code, Reason: reason, Instructors: []string{}}
+							quarantined[code] = entry
+						}
+						entry.ReviewCount++
+						if !slices.Contains(entry.Instructors, instructor.ProfessorName) {
+							entry.Instructors = append(entry.Instructors, instructor.ProfessorName)
+						}
+						continue
+					}
+					byCode[review.CourseCode] = append(byCode[review.CourseCode], review)
+				}
+
+				for code, courseReviews := range byCode {
+					entry := instructor
+					entry.Reviews = courseReviews
+					entry.ReviewCount = len(courseReviews)
+					resolved[code] = append(resolved[code], CourseReviewData{CourseCode: code, Instructors: []InstructorSummary{entry}})
+				}
+			}
+		}
+	}
+
+	quarantine := make([]QuarantinedCourseCode, 0, len(quarantined))
+	for _, entry := range quarantined {
+		quarantine = append(quarantine, *entry)
+	}
+	sort.Slice(quarantine, func(i, j int) bool {
+		if quarantine[i].ReviewCount != quarantine[j].ReviewCount {
+			return quarantine[i].ReviewCount > quarantine[j].ReviewCount
+		}
+		return quarantine[i].CourseCode < quarantine[j].CourseCode
+	})
+
+	return resolved, quarantine
+}
+
+// loadKeywords sets the keywords extracted by extract-keywords on every
+// instructor and course. Keywords are optional, so a missing file is ignored.
+func (s *ReviewStore) loadKeywords(instructorReviews map[string]InstructorReviewData, courseReviews map[string]CourseReviewData) error {
+	if _, err := os.Stat(s.keywordsFile); os.IsNotExist(err) {
+		return nil
+	}
+
+	var keywords ReviewKeywords
+	if err := readJSONFile(s.keywordsFile, &keywords); err != nil {
+		return err
+	}
+
+	for file, reviewData := range instructorReviews {
+		reviewData.Keywords = keywords.Instructors[file]
+		instructorReviews[file] = reviewData
+	}
+	for code, courseData := range courseReviews {
+		courseData.Keywords = keywords.Courses[code]
+		courseReviews[code] = courseData
+	}
+	return nil
+}
+
+// summarizeCourses returns the review summary of every course, by course code.
+func summarizeCourses(courseReviews map[string]CourseReviewData) []CourseSummary {
+	summaries := make([]CourseSummary, 0, len(courseReviews))
+	for code, courseData := range courseReviews {
+		summaries = append(summaries, CourseSummary{
+			CourseCode:    code,
+			TotalReviews:  courseData.TotalReviews,
+			AvgRating:     courseData.AvgRating,
+			AvgDifficulty: courseData.AvgDifficulty,
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].CourseCode < summaries[j].CourseCode
+	})
+	return summaries
+}
+
+// summarizeInstructors returns the overall ratings of every identified
+// instructor, merging the review files of instructors with more than one.
+func summarizeInstructors(instructorReviews map[string]InstructorReviewData) map[string]InstructorRatings {
+	filesByID := make(map[string][]InstructorReviewData)
+	for _, reviewData := range instructorReviews {
+		if reviewData.InstructorID == "" {
+			continue
+		}
+		filesByID[reviewData.InstructorID] = append(filesByID[reviewData.InstructorID], reviewData)
+	}
+
+	ratings := make(map[string]InstructorRatings, len(filesByID))
+	for id, files := range filesByID {
+		// Most reviewed first, as in the alias table
+		sort.Slice(files, func(i, j int) bool {
+			if len(files[i].Reviews) != len(files[j].Reviews) {
+				return len(files[i].Reviews) > len(files[j].Reviews)
+			}
+			return files[i].ProfessorID < files[j].ProfessorID
+		})
+		reviewData := reviews.AggregateInstructor(files)
+
+		reviewCount := lo.FromPtr(reviews.ParseInt(reviewData.TotalRatings))
+		if reviewCount == 0 {
+			reviewCount = len(reviewData.Reviews)
+		}
+		ratings[id] = InstructorRatings{
+			AvgRating:      reviews.ParseFloat(reviewData.OverallRating),
+			AvgDifficulty:  reviews.ParseFloat(reviewData.DifficultyLevel),
+			WouldTakeAgain: reviews.ParseFloat(reviewData.WouldTakeAgain),
+			ReviewCount:    reviewCount,
+		}
+	}
+	return ratings
+}
+
+// resolveInstructorIDs sets the stable ID of every reviewed instructor.
+func (s *ReviewStore) resolveInstructorIDs(instructorReviews map[string]InstructorReviewData, courseFiles map[string][]CourseReviewData, summaries []ProfessorSummary) {
+	if s.identities == nil {
+		return
+	}
+
+	for file, reviewData := range instructorReviews {
+		reviewData.InstructorID = s.identities.ReviewFileID(file)
+		instructorReviews[file] = reviewData
+	}
+
+	for code, files := range courseFiles {
+		courseDepts := []string{courseDeptPattern.FindString(code)}
+		for _, courseData := range files {
+			for i, instructor := range courseData.Instructors {
+				courseData.Instructors[i].InstructorID = s.identities.ResolveInDepts(instructor.ProfessorName, courseDepts)
+			}
+		}
+	}
+
+	for i, summary := range summaries {
+		summaries[i].InstructorID = s.identities.Resolve(summary.Name, "")
+	}
+}
+
+func (s *ReviewStore) reloadIfNeeded() error {
+	s.mu.RLock()
+	shouldReload := time.Since(s.lastLoaded) > time.Hour
+	s.mu.RUnlock()
+
+	if shouldReload {
+		if err := s.loadReviews(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LastLoaded returns when the reviews were last loaded.
+func (s *ReviewStore) LastLoaded() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastLoaded
+}
+
+// GetInstructor returns the reviews of an instructor review file. The file path
+// is relative to the instructor_reviews directory, e.g. "Accounting/Angela_Lin.json".
+func (s *ReviewStore) GetInstructor(ctx context.Context, file string) (InstructorReviewData, error) {
+	if err := s.reloadIfNeeded(); err != nil {
+		return InstructorReviewData{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	reviewData, found := s.instructorReviews[file]
+	if !found {
+		return InstructorReviewData{}, ErrNotFound
+	}
+	return reviewData, nil
+}
+
+// FindInstructor returns the reviews of an instructor by review file name,
+// trying "Angela Lin", "Angela_Lin" and "AngelaLin" case-insensitively.
+func (s *ReviewStore) FindInstructor(ctx context.Context, name string) (InstructorReviewData, error) {
+	if err := s.reloadIfNeeded(); err != nil {
+		return InstructorReviewData{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	variations := []string{
+		strings.ReplaceAll(name, " ", "_"),
+		strings.ReplaceAll(name, " ", ""),
+	}
+	for _, variation := range variations {
+		if file, found := s.instructorFiles[strings.ToLower(variation)]; found {
+			return s.instructorReviews[file], nil
+		}
+	}
+	return InstructorReviewData{}, ErrNotFound
+}
+
+// GetCourse returns the reviews of a course code such as "CMPT225" or "cmpt 225".
+func (s *ReviewStore) GetCourse(ctx context.Context, courseCode string) (CourseReviewData, error) {
+	if err := s.reloadIfNeeded(); err != nil {
+		return CourseReviewData{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	courseData, found := s.courseReviews[strings.ToUpper(strings.ReplaceAll(courseCode, " ", ""))]
+	if !found {
+		return CourseReviewData{}, ErrNotFound
+	}
+	return courseData, nil
+}
+
+// GetInstructorRatings returns the ratings of an instructor by stable ID,
+// including the averages of their reviews of a course code such as "CMPT225".
+func (s *ReviewStore) GetInstructorRatings(ctx context.Context, instructorID, courseCode string) (InstructorRatings, error) {
+	if err := s.reloadIfNeeded(); err != nil {
+		return InstructorRatings{}, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ratings, found := s.instructorRatings[instructorID]
+	if !found {
+		return InstructorRatings{}, ErrNotFound
+	}
+
+	for _, instructor := range s.courseReviews[strings.ToUpper(courseCode)].Instructors {
+		if instructor.InstructorID == instructorID && instructor.ReviewCount > 0 {
+			ratings.CourseAvgRating = lo.EmptyableToPtr(instructor.AvgRating)
+			ratings.CourseAvgDifficulty = lo.EmptyableToPtr(instructor.AvgDifficulty)
+			ratings.CourseReviewCount = instructor.ReviewCount
+			break
+		}
+	}
+	return ratings, nil
+}
+
+// GetQuarantine returns the review course codes that couldn't be resolved to a
+// catalog course, most reviewed first.
+func (s *ReviewStore) GetQuarantine(ctx context.Context) ([]QuarantinedCourseCode, error) {
+	if err := s.reloadIfNeeded(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.quarantine, nil
+}
+
+// GetAllInstructors returns the review summary of every instructor.
+func (s *ReviewStore) GetAllInstructors(ctx context.Context) ([]ProfessorSummary, error) {
+	if err := s.reloadIfNeeded(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.instructorSummaries, nil
+}
+
+// GetAllCourses returns the review summary of every course.
+func (s *ReviewStore) GetAllCourses(ctx context.Context) ([]CourseSummary, error) {
+	if err := s.reloadIfNeeded(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.courseSummaries, nil
+}
+
+// ExtractKeywords returns the most distinctive phrases of every instructor and
+// course. Instructors are scored against all instructors and courses against
+// all courses, leaving out instructor names and course departments. Scoring
+// every review takes seconds, so this runs offline in extract-keywords rather
+// than on every load.
+func (s *ReviewStore) ExtractKeywords() ReviewKeywords {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keywords := ReviewKeywords{
+		Instructors: make(map[string][]Keyword, len(s.instructorReviews)),
+		Courses:     make(map[string][]Keyword, len(s.courseReviews)),
+	}
+
+	instructorIndex := reviews.NewKeywordIndex()
+	for file, reviewData := range s.instructorReviews {
+		instructorIndex.Add(file, reviewData.Reviews)
+	}
+	for file, reviewData := range s.instructorReviews {
+		if found := instructorIndex.Keywords(file, []string{reviewData.ProfessorName}, reviews.DefaultKeywords); len(found) > 0 {
+			keywords.Instructors[file] = found
+		}
+	}
+
+	courseIndex := reviews.NewKeywordIndex()
+	for code, courseData := range s.courseReviews {
+		courseIndex.Add(code, lo.FlatMap(courseData.Instructors, func(instructor InstructorSummary, _ int) []Review {
+			return instructor.Reviews
+		}))
+	}
+	for code, courseData := range s.courseReviews {
+		exclude := []string{reviews.Dept(code)}
+		for _, instructor := range courseData.Instructors {
+			exclude = append(exclude, instructor.ProfessorName)
+		}
+		if found := courseIndex.Keywords(code, exclude, reviews.DefaultKeywords); len(found) > 0 {
+			keywords.Courses[code] = found
+		}
+	}
+
+	return keywords
+}
+
+func readJSONFile(filePath string, v any) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("error reading file %s: %v", filePath, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("error parsing JSON %s: %v", filePath, err)
+	}
+	return nil
+}
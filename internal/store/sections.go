@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/brianrahadi/sfucourses-api/internal/graph"
 	. "github.com/brianrahadi/sfucourses-api/internal/model"
 	"github.com/brianrahadi/sfucourses-api/internal/utils"
 	"github.com/samber/lo"
@@ -16,13 +17,16 @@ import (
 
 type SectionsStore struct {
 	cachedSections map[string][]CourseWithSectionDetails
+	teachingGraph  *graph.Teaching
+	identities     instructorResolver
 	lastLoaded     time.Time
 	mu             sync.RWMutex
 }
 
-func NewSectionStore() (*SectionsStore, error) {
+func NewSectionStore(identities instructorResolver) (*SectionsStore, error) {
 	store := &SectionsStore{
 		cachedSections: make(map[string][]CourseWithSectionDetails),
+		identities:     identities,
 	}
 
 	if err := store.loadSections(); err != nil {
@@ -36,7 +40,9 @@ func (s *SectionsStore) ForceReload() error {
 	return s.loadSections()
 }
 
-func (s *SectionsStore) loadSections() error {
+func (s *SectionsStore) loadSections() (err error) {
+	defer observeReload("sections", time.Now(), &err)
+
 	scheduleFiles := utils.GetSectionFilePaths()
 
 	newSections := make(map[string][]CourseWithSectionDetails)
@@ -52,17 +58,40 @@ func (s *SectionsStore) loadSections() error {
 		if err := json.Unmarshal(data, &courses); err != nil {
 			return fmt.Errorf("error parsing JSON for term %s: %v", term, err)
 		}
+		s.resolveInstructorIDs(courses)
 		newSections[term] = courses
 	}
 
+	teachingGraph := graph.BuildTeaching(newSections)
+
 	s.mu.Lock()
 	s.cachedSections = newSections
+	s.teachingGraph = teachingGraph
 	s.lastLoaded = time.Now()
 	s.mu.Unlock()
 
 	return nil
 }
 
+// resolveInstructorIDs sets the stable ID of every section instructor.
+func (s *SectionsStore) resolveInstructorIDs(courses []CourseWithSectionDetails) {
+	if s.identities == nil {
+		return
+	}
+
+	for _, course := range courses {
+		for _, section := range course.SectionDetails {
+			for i, instructor := range section.Instructors {
+				id := s.identities.Resolve(instructor.Name, instructor.Email)
+				if id == "" {
+					id = s.identities.ResolveInDepts(instructor.Name, []string{course.Dept})
+				}
+				section.Instructors[i].ID = id
+			}
+		}
+	}
+}
+
 func (s *SectionsStore) reloadIfNeeded() error {
 	s.mu.RLock()
 	shouldReload := time.Since(s.lastLoaded) > 5*time.Minute // Check every 5 minutes
@@ -76,6 +105,13 @@ func (s *SectionsStore) reloadIfNeeded() error {
 	return nil
 }
 
+// LastLoaded returns when the sections were last loaded.
+func (s *SectionsStore) LastLoaded() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastLoaded
+}
+
 func (s *SectionsStore) Get(ctx context.Context, year, term, dept, number string) ([]CourseWithSectionDetails, error) {
 	if err := s.reloadIfNeeded(); err != nil {
 		return nil, err
@@ -117,3 +153,14 @@ func (s *SectionsStore) Get(ctx context.Context, year, term, dept, number string
 
 	return nil, ErrNotFound
 }
+
+// GetTeachingGraph returns the instructor-course graph of all loaded terms.
+func (s *SectionsStore) GetTeachingGraph(ctx context.Context) (*graph.Teaching, error) {
+	if err := s.reloadIfNeeded(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.teachingGraph, nil
+}
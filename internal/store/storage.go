@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"log"
+	"time"
 
+	"github.com/brianrahadi/sfucourses-api/internal/graph"
+	"github.com/brianrahadi/sfucourses-api/internal/metrics"
 	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/brianrahadi/sfucourses-api/internal/search"
 )
 
 var (
@@ -15,29 +19,80 @@ var (
 type Storage struct {
 	Outlines interface {
 		Get(context.Context, string, string) ([]model.CourseOutline, error)
+		Search(context.Context, search.Query) ([]model.CourseSearchResult, error)
 		GetPrereqMap() model.PrereqMap
+		LastLoaded() time.Time
 		ForceReload() error
 	}
 
 	Sections interface {
 		Get(context.Context, string, string, string, string) ([]model.CourseWithSectionDetails, error)
+		GetTeachingGraph(context.Context) (*graph.Teaching, error)
+		LastLoaded() time.Time
 		ForceReload() error
 	}
 
 	Instructors interface {
 		Get(context.Context, string, string, string) ([]model.InstructorResponse, error)
+		Search(context.Context, string, int) ([]model.InstructorSearchResult, error)
+		LastLoaded() time.Time
 		ForceReload() error
 	}
+
+	Identities interface {
+		Lookup(context.Context, string) (model.InstructorIdentity, error)
+		Resolve(string, string) string
+		ResolveInDepts(string, []string) string
+		ReviewFileID(string) string
+		LastLoaded() time.Time
+		ForceReload() error
+	}
+
+	Reviews interface {
+		GetInstructor(context.Context, string) (model.InstructorReviewData, error)
+		FindInstructor(context.Context, string) (model.InstructorReviewData, error)
+		GetCourse(context.Context, string) (model.CourseReviewData, error)
+		GetAllInstructors(context.Context) ([]model.ProfessorSummary, error)
+		GetAllCourses(context.Context) ([]model.CourseSummary, error)
+		GetInstructorRatings(context.Context, string, string) (model.InstructorRatings, error)
+		GetQuarantine(context.Context) ([]model.QuarantinedCourseCode, error)
+		LastLoaded() time.Time
+		ForceReload() error
+	}
+}
+
+// instructorResolver resolves instructor names and emails to stable instructor IDs.
+type instructorResolver interface {
+	Resolve(name, email string) string
+	ResolveInDepts(name string, depts []string) string
+	ReviewFileID(file string) string
+}
+
+// outlineLister lists course outlines, used as the catalog of valid course codes.
+type outlineLister interface {
+	Get(ctx context.Context, dept, number string) ([]model.CourseOutline, error)
+}
+
+// observeReload records the duration and outcome of a store reload that
+// started at start, for deferring with the reload's named error result.
+func observeReload(store string, start time.Time, err *error) {
+	metrics.ObserveReload(store, time.Since(start), *err)
 }
 
 func NewStorage() Storage {
+	identities, err := NewIdentityStore()
+	if err != nil {
+		log.Fatal("Error loading identities store")
+		identities = &IdentityStore{}
+	}
+
 	outlines, err := NewOutlineStore()
 
 	if err != nil {
 		log.Fatal("Error loading outlines store")
 		outlines = &OutlineStore{}
 	}
-	sections, err := NewSectionStore()
+	sections, err := NewSectionStore(identities)
 	if err != nil {
 		log.Fatal("Error loading sections store")
 		sections = &SectionsStore{}
@@ -49,9 +104,17 @@ func NewStorage() Storage {
 		instructors = &InstructorStore{}
 	}
 
+	reviews, err := NewReviewStore(identities, outlines)
+	if err != nil {
+		log.Fatal("Error loading reviews store")
+		reviews = &ReviewStore{}
+	}
+
 	return Storage{
 		Outlines:    outlines,
 		Sections:    sections,
 		Instructors: instructors,
+		Identities:  identities,
+		Reviews:     reviews,
 	}
 }
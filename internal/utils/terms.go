@@ -1,6 +1,12 @@
 package utils
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // TermCodes is the single source of truth for all academic term codes.
 // Format: "YYYY-season" (e.g., "2024-spring", "2025-fall")
@@ -78,3 +84,56 @@ func SplitTermCode(termCode string) []string {
 	}
 	return parts
 }
+
+// TermCodeFromTerm converts a display term into a term code.
+// Example: TermCodeFromTerm("Fall 2024") returns "2024-fall"
+// Returns an empty string if the term is not in the "Season YYYY" format.
+func TermCodeFromTerm(term string) string {
+	parts := strings.Fields(term)
+	if len(parts) != 2 {
+		return ""
+	}
+	return parts[1] + "-" + strings.ToLower(parts[0])
+}
+
+// TermFromTermCode converts a term code into a display term.
+// Example: TermFromTermCode("2024-fall") returns "Fall 2024"
+func TermFromTermCode(termCode string) string {
+	parts := SplitTermCode(termCode)
+	if len(parts) != 2 || parts[1] == "" {
+		return termCode
+	}
+	return strings.ToUpper(parts[1][:1]) + parts[1][1:] + " " + parts[0]
+}
+
+// termSeasons lists the seasons of an academic year in calendar order.
+var termSeasons = []string{"spring", "summer", "fall"}
+
+// TermSortKey returns a key that orders term codes chronologically.
+// Example: TermSortKey("2024-fall") > TermSortKey("2024-spring")
+// Returns -1 if the term code is not in the "YYYY-season" format.
+func TermSortKey(termCode string) int {
+	parts := SplitTermCode(strings.ToLower(termCode))
+	if len(parts) != 2 {
+		return -1
+	}
+	year, err := strconv.Atoi(parts[0])
+	season := slices.Index(termSeasons, parts[1])
+	if err != nil || season < 0 {
+		return -1
+	}
+	return year*len(termSeasons) + season
+}
+
+// TermCodeFromSortKey converts a key returned by TermSortKey back into a term code.
+// Example: TermCodeFromSortKey(TermSortKey("2024-fall")) returns "2024-fall"
+func TermCodeFromSortKey(key int) string {
+	return fmt.Sprintf("%d-%s", key/len(termSeasons), termSeasons[key%len(termSeasons)])
+}
+
+// TermCodeForDate returns the code of the term a date falls in, where Jan-Apr
+// is spring, May-Aug is summer and Sep-Dec is fall.
+// Example: TermCodeForDate(time.Date(2024, time.October, 1, 0, 0, 0, 0, time.UTC)) returns "2024-fall"
+func TermCodeForDate(date time.Time) string {
+	return fmt.Sprintf("%d-%s", date.Year(), termSeasons[(int(date.Month())-1)/4])
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/brianrahadi/sfucourses-api/internal/store"
+)
+
+const (
+	BASE_PATH   = "./internal/store/json"
+	RESULT_PATH = BASE_PATH + "/review_keywords.json"
+)
+
+// extract-keywords scores the phrases of every review with TF-IDF and writes
+// the most distinctive ones of every instructor and course, such as
+// "curved exams" or "heavy workload", next to the review summaries. Review
+// course codes are resolved against the outline catalog first, as in the API.
+func main() {
+	outlines, err := store.NewOutlineStore()
+	if err != nil {
+		fmt.Printf("Error loading outlines: %v\n", err)
+		return
+	}
+
+	reviewStore, err := store.NewReviewStore(nil, outlines)
+	if err != nil {
+		fmt.Printf("Error loading reviews: %v\n", err)
+		return
+	}
+
+	keywords := reviewStore.ExtractKeywords()
+
+	jsonData, err := json.MarshalIndent(keywords, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling keywords to JSON: %v\n", err)
+		return
+	}
+
+	if err := os.WriteFile(RESULT_PATH, jsonData, 0644); err != nil {
+		fmt.Printf("Error writing keywords: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Successfully wrote keywords of %d instructors and %d courses to %s\n",
+		len(keywords.Instructors), len(keywords.Courses), RESULT_PATH)
+}
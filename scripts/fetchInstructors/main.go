@@ -3,17 +3,19 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"slices"
 	"strings"
 
+	"github.com/brianrahadi/sfucourses-api/internal/identity"
 	"github.com/brianrahadi/sfucourses-api/internal/model"
 	internalUtils "github.com/brianrahadi/sfucourses-api/internal/utils"
+	utils "github.com/brianrahadi/sfucourses-api/scripts"
 	"github.com/samber/lo"
 )
 
 const (
-	RESULT_PATH = "./internal/store/json/instructors.json"
+	RESULT_PATH  = "./internal/store/json/instructors.json"
+	ALIASES_PATH = "./internal/store/json/instructor_aliases.json"
 )
 
 type InstructorOffering struct {
@@ -24,7 +26,9 @@ type InstructorOffering struct {
 }
 
 type Instructor struct {
+	ID        string               `json:"id,omitempty"`
 	Name      string               `json:"name"`
+	Aliases   []string             `json:"aliases,omitempty"`
 	Offerings []InstructorOffering `json:"offerings"`
 }
 
@@ -48,44 +52,84 @@ func main() {
 		return
 	}
 
-	instructorMap := make(map[string]*Instructor)
+	aliases, err := internalUtils.ReadCoursesFromJSON[[]model.InstructorIdentity](ALIASES_PATH)
+	if err != nil {
+		fmt.Printf("Error reading alias table, instructors are keyed by name: %v\n", err)
+	}
+	resolver := identity.NewResolver(aliases)
 
+	outlineTitles := make(map[string]string, len(outlines))
 	for _, outline := range outlines {
-		if outline.Dept == "" || outline.Number == "" {
+		outlineTitles[outline.Dept+" "+outline.Number] = outline.Title
+	}
+
+	instructorMap := make(map[string]*Instructor)
+
+	// Sections carry instructor emails, which tell namesakes apart better than names alone
+	for _, termCode := range internalUtils.GetTermCodes() {
+		courses, err := internalUtils.ReadCoursesFromJSON[[]model.CourseWithSectionDetails](internalUtils.GetSectionFilePath(termCode))
+		if err != nil {
+			fmt.Printf("Error reading sections from JSON %s: %v\n", termCode, err)
 			continue
 		}
+		term := internalUtils.TermFromTermCode(termCode)
 
-		for _, offering := range outline.Offerings {
-			for _, instructorName := range offering.Instructors {
-				if strings.TrimSpace(instructorName) == "" {
-					continue
-				}
+		for _, course := range courses {
+			if course.Dept == "" || course.Number == "" {
+				continue
+			}
 
-				cleanName := strings.TrimSpace(instructorName)
+			title, ok := outlineTitles[course.Dept+" "+course.Number]
+			if !ok {
+				title = course.Title
+			}
 
-				if _, exists := instructorMap[cleanName]; !exists {
-					instructorMap[cleanName] = &Instructor{
-						Name:      cleanName,
-						Offerings: []InstructorOffering{},
+			for _, section := range course.SectionDetails {
+				for _, sectionInstructor := range section.Instructors {
+					cleanName := strings.TrimSpace(sectionInstructor.Name)
+					if cleanName == "" {
+						continue
 					}
-				}
 
-				newOffering := InstructorOffering{
-					Dept:   outline.Dept,
-					Number: outline.Number,
-					Term:   offering.Term,
-					Title:  outline.Title,
-				}
+					key, name := cleanName, cleanName
+					id := resolver.Resolve(cleanName, sectionInstructor.Email)
+					if id == "" {
+						id = resolver.ResolveInDepts(cleanName, []string{course.Dept})
+					}
+					if identity, ok := resolver.Get(id); ok {
+						key, name = identity.ID, identity.Name
+					}
+
+					if _, exists := instructorMap[key]; !exists {
+						instructorMap[key] = &Instructor{
+							ID:        id,
+							Name:      name,
+							Aliases:   []string{},
+							Offerings: []InstructorOffering{},
+						}
+					}
+					instructor := instructorMap[key]
 
-				instructor := instructorMap[cleanName]
-				offeringExists := lo.ContainsBy(instructor.Offerings, func(existing InstructorOffering) bool {
-					return existing.Dept == newOffering.Dept &&
-						existing.Number == newOffering.Number &&
-						existing.Term == newOffering.Term
-				})
+					if alias := identity.CleanName(cleanName); alias != name && !lo.Contains(instructor.Aliases, alias) {
+						instructor.Aliases = append(instructor.Aliases, alias)
+					}
+
+					newOffering := InstructorOffering{
+						Dept:   course.Dept,
+						Number: course.Number,
+						Term:   term,
+						Title:  title,
+					}
 
-				if !offeringExists {
-					instructor.Offerings = append(instructor.Offerings, newOffering)
+					offeringExists := lo.ContainsBy(instructor.Offerings, func(existing InstructorOffering) bool {
+						return existing.Dept == newOffering.Dept &&
+							existing.Number == newOffering.Number &&
+							existing.Term == newOffering.Term
+					})
+
+					if !offeringExists {
+						instructor.Offerings = append(instructor.Offerings, newOffering)
+					}
 				}
 			}
 		}
@@ -165,7 +209,7 @@ func writeInstructorsToJSON(instructors []Instructor, filePath string) error {
 		return fmt.Errorf("error marshaling instructors to JSON: %w", err)
 	}
 
-	err = os.WriteFile(filePath, jsonData, 0644)
+	err = utils.WriteFileAtomic(filePath, jsonData)
 	if err != nil {
 		return fmt.Errorf("error writing instructors to file: %w", err)
 	}
@@ -87,7 +87,7 @@ func main() {
 	}
 
 	// Write to file
-	err = os.WriteFile(resultFilePath, jsonData, 0644)
+	err = utils.WriteFileAtomic(resultFilePath, jsonData)
 	if err != nil {
 		fmt.Printf("Error writing to file: %v\n", err)
 		os.Exit(1)
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	"github.com/brianrahadi/sfucourses-api/internal/reviews"
+	"github.com/samber/lo"
+)
+
+const (
+	BASE_PATH           = "./internal/store/json"
+	INSTRUCTOR_REVIEWS  = "instructor_reviews"
+	COURSE_REVIEWS      = "course_reviews"
+	INSTRUCTORS_SUMMARY = "all_instructor_reviews.json"
+	COURSES_SUMMARY     = "all_course_reviews.json"
+	ALL_COURSES         = "ALL_COURSES.json"
+	MAX_LISTED          = 20
+)
+
+// change is the outcome of writing one file.
+type change int
+
+const (
+	unchanged change = iota
+	added
+	modified
+	removed
+)
+
+// report counts the changed files of one artifact, listing the first few.
+type report struct {
+	name   string
+	counts map[change]int
+	files  map[change][]string
+}
+
+func newReport(name string) *report {
+	return &report{name: name, counts: make(map[change]int), files: make(map[change][]string)}
+}
+
+func (r *report) add(c change, file string) {
+	r.counts[c]++
+	if c != unchanged && len(r.files[c]) < MAX_LISTED {
+		r.files[c] = append(r.files[c], file)
+	}
+}
+
+func (r *report) print() {
+	fmt.Printf("%s: %d added, %d changed, %d removed, %d unchanged\n",
+		r.name, r.counts[added], r.counts[modified], r.counts[removed], r.counts[unchanged])
+	for _, c := range []change{added, modified, removed} {
+		label := map[change]string{added: "+", modified: "~", removed: "-"}[c]
+		for _, file := range r.files[c] {
+			fmt.Printf("  %s %s\n", label, file)
+		}
+		if more := r.counts[c] - len(r.files[c]); more > 0 {
+			fmt.Printf("  %s ... and %d more\n", label, more)
+		}
+	}
+}
+
+// ingest-reviews builds the review JSON tree from a raw review export: one
+// file per instructor, one file per course and the instructor and course
+// overviews. Invalid instructors and reviews are dropped and reported, and
+// every artifact is compared with the existing tree to report what changed.
+func main() {
+	input := flag.String("input", "", "path to the review export (.json or .csv)")
+	format := flag.String("format", "", "export format, json or csv (default: from the file extension)")
+	out := flag.String("out", BASE_PATH, "directory holding the review JSON tree")
+	dryRun := flag.Bool("dry-run", false, "report changes without writing files")
+	prune := flag.Bool("prune", false, "remove instructor and course files missing from the export")
+	verbose := flag.Bool("v", false, "list every validation warning")
+	flag.Parse()
+
+	if *input == "" {
+		fmt.Println("Usage: ingest-reviews -input <export.json|export.csv> [-format json|csv] [-out dir] [-dry-run] [-prune] [-v]")
+		fmt.Println("JSON exports are an array of instructor review files.")
+		fmt.Printf("CSV exports have one review per row with the columns: %s\n", strings.Join(reviews.ExportColumns, ", "))
+		os.Exit(2)
+	}
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*input)), ".")
+	}
+
+	file, err := os.Open(*input)
+	if err != nil {
+		fmt.Printf("Error opening export: %v\n", err)
+		os.Exit(1)
+	}
+
+	var instructors []model.InstructorReviewData
+	switch *format {
+	case "json":
+		instructors, err = reviews.ReadExportJSON(file)
+	case "csv":
+		instructors, err = reviews.ReadExportCSV(file)
+	default:
+		err = fmt.Errorf("unknown format %q, expected json or csv", *format)
+	}
+	file.Close()
+	if err != nil {
+		fmt.Printf("Error reading export: %v\n", err)
+		os.Exit(1)
+	}
+
+	valid, issues := reviews.Validate(instructors)
+	artifacts, buildIssues := reviews.BuildArtifacts(valid)
+	issues = append(issues, buildIssues...)
+	printIssues(issues, *verbose)
+
+	reviewCount := lo.SumBy(valid, func(i model.InstructorReviewData) int { return len(i.Reviews) })
+	fmt.Printf("Read %d instructors, kept %d instructors with %d reviews across %d courses\n",
+		len(instructors), len(valid), reviewCount, len(artifacts.Courses))
+
+	instructorFiles := make(map[string]any, len(artifacts.Instructors))
+	for relativePath, reviewData := range artifacts.Instructors {
+		instructorFiles[filepath.FromSlash(relativePath)] = reviewData
+	}
+	courseFiles := make(map[string]any, len(artifacts.Courses))
+	for code, courseData := range artifacts.Courses {
+		courseFiles[code+".json"] = courseData
+	}
+
+	reports := []*report{
+		syncDir(filepath.Join(*out, INSTRUCTOR_REVIEWS), instructorFiles, decodeAs[model.InstructorReviewData], *dryRun, *prune),
+		syncDir(filepath.Join(*out, COURSE_REVIEWS), courseFiles, decodeAs[model.CourseReviewData], *dryRun, *prune),
+		syncFile(filepath.Join(*out, INSTRUCTORS_SUMMARY), artifacts.InstructorSummaries, decodeAs[[]model.ProfessorSummary], *dryRun),
+		syncFile(filepath.Join(*out, COURSES_SUMMARY), artifacts.CourseSummaries, decodeAs[[]model.CourseSummary], *dryRun),
+		syncFile(filepath.Join(*out, COURSE_REVIEWS, ALL_COURSES), artifacts.CourseSummaries, decodeAs[[]model.CourseSummary], *dryRun),
+	}
+	for _, r := range reports {
+		r.print()
+	}
+
+	if *dryRun {
+		fmt.Println("Dry run, no files were written")
+		return
+	}
+	if reports[0].counts[added]+reports[0].counts[removed] > 0 {
+		fmt.Println("Instructor review files were added or removed, run sync-aliases to update the alias table")
+	}
+	if lo.SomeBy(reports, func(r *report) bool { return r.counts[added]+r.counts[modified]+r.counts[removed] > 0 }) {
+		fmt.Println("Reviews changed, run extract-keywords to update the review keywords")
+	}
+}
+
+func printIssues(issues []reviews.Issue, verbose bool) {
+	errorCount := lo.CountBy(issues, func(i reviews.Issue) bool { return i.Error })
+	fmt.Printf("Validation: %d errors, %d warnings\n", errorCount, len(issues)-errorCount)
+
+	listed := 0
+	for _, issue := range issues {
+		if issue.Error || verbose || listed < MAX_LISTED {
+			fmt.Printf("  %s\n", issue)
+			if !issue.Error {
+				listed++
+			}
+		}
+	}
+	if hidden := len(issues) - errorCount - listed; hidden > 0 {
+		fmt.Printf("  ... and %d more warnings, use -v to list them\n", hidden)
+	}
+}
+
+// syncDir writes every file of a directory tree, keyed by path relative to
+// dir. JSON files missing from files are removed if prune is set.
+func syncDir(dir string, files map[string]any, decode func([]byte) (any, error), dryRun, prune bool) *report {
+	r := newReport(filepath.Base(dir))
+
+	relativePaths := lo.Keys(files)
+	sort.Strings(relativePaths)
+	for _, relativePath := range relativePaths {
+		r.add(writeIfChanged(filepath.Join(dir, relativePath), files[relativePath], decode, dryRun), relativePath)
+	}
+
+	existing, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	nested, _ := filepath.Glob(filepath.Join(dir, "*", "*.json"))
+	for _, filePath := range append(existing, nested...) {
+		relativePath, err := filepath.Rel(dir, filePath)
+		if err != nil || relativePath == ALL_COURSES {
+			continue
+		}
+		if _, kept := files[relativePath]; kept || !prune {
+			continue
+		}
+		if !dryRun {
+			if err := os.Remove(filePath); err != nil {
+				fmt.Printf("Error removing %s: %v\n", filePath, err)
+				continue
+			}
+		}
+		r.add(removed, relativePath)
+	}
+
+	return r
+}
+
+func syncFile(filePath string, v any, decode func([]byte) (any, error), dryRun bool) *report {
+	r := newReport(filepath.Base(filePath))
+	r.add(writeIfChanged(filePath, v, decode, dryRun), filepath.Base(filePath))
+	return r
+}
+
+// writeIfChanged writes v as JSON unless the existing file decodes to the same
+// data, so that files written by other tools aren't rewritten for formatting.
+func writeIfChanged(filePath string, v any, decode func([]byte) (any, error), dryRun bool) change {
+	data, err := marshal(v)
+	if err != nil {
+		fmt.Printf("Error marshaling %s: %v\n", filePath, err)
+		return unchanged
+	}
+
+	result := added
+	if existing, err := os.ReadFile(filePath); err == nil {
+		result = modified
+		if decoded, err := decode(existing); err == nil {
+			if normalized, err := marshal(decoded); err == nil && bytes.Equal(normalized, data) {
+				return unchanged
+			}
+		}
+	}
+
+	if dryRun {
+		return result
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		fmt.Printf("Error creating directory for %s: %v\n", filePath, err)
+		return unchanged
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		fmt.Printf("Error writing %s: %v\n", filePath, err)
+		return unchanged
+	}
+	return result
+}
+
+func decodeAs[T any](data []byte) (any, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+func marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -30,6 +30,7 @@ echo "Building binaries..."
 go build -o bin/fetch-sections scripts/fetchSections/main.go
 go build -o bin/sync-offerings scripts/syncOfferings/main.go
 go build -o bin/sync-instructors scripts/syncInstructors/main.go
+go build -o bin/sync-aliases scripts/syncAliases/main.go
 
 # Run the commands
 echo "Running data sync commands..."
@@ -37,6 +38,7 @@ echo "Running data sync commands..."
 ./bin/fetch-sections $nextYear $nextTerm
 ./bin/sync-offerings
 ./bin/sync-instructors
+./bin/sync-aliases
 
 # Add any changes to git
 git add internal/store/json/ 
\ No newline at end of file
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/brianrahadi/sfucourses-api/internal/identity"
+	"github.com/brianrahadi/sfucourses-api/internal/model"
+	internalUtils "github.com/brianrahadi/sfucourses-api/internal/utils"
+	utils "github.com/brianrahadi/sfucourses-api/scripts"
+	"github.com/samber/lo"
+)
+
+const (
+	BASE_PATH          = "./internal/store/json"
+	RESULT_PATH        = BASE_PATH + "/instructor_aliases.json"
+	INSTRUCTOR_REVIEWS = BASE_PATH + "/instructor_reviews"
+)
+
+var courseDeptPattern = regexp.MustCompile(`^([A-Za-z]{2,5})`)
+
+// sync-aliases resolves the instructors found in sections and review files
+// into stable identities and writes them to the alias table. Identities already
+// in the alias table keep their IDs, so the table can be edited by hand to merge
+// or split instructors.
+func main() {
+	var seed []model.InstructorIdentity
+	if _, err := os.Stat(RESULT_PATH); err == nil {
+		seed, err = internalUtils.ReadCoursesFromJSON[[]model.InstructorIdentity](RESULT_PATH)
+		if err != nil {
+			fmt.Printf("Error reading alias table: %v\n", err)
+			return
+		}
+	}
+
+	builder := identity.NewBuilder(seed)
+
+	for _, term := range internalUtils.GetTermCodes() {
+		courses, err := internalUtils.ReadCoursesFromJSON[[]model.CourseWithSectionDetails](internalUtils.GetSectionFilePath(term))
+		if err != nil {
+			fmt.Printf("Error reading sections from JSON %s: %v\n", term, err)
+			continue
+		}
+
+		for _, course := range courses {
+			for _, section := range course.SectionDetails {
+				for _, instructor := range section.Instructors {
+					builder.Add(identity.Observation{
+						Name:  instructor.Name,
+						Email: instructor.Email,
+						Depts: []string{course.Dept},
+					})
+				}
+			}
+		}
+	}
+
+	reviewFiles, err := filepath.Glob(filepath.Join(INSTRUCTOR_REVIEWS, "*", "*.json"))
+	if err != nil {
+		fmt.Printf("Error listing review files: %v\n", err)
+		return
+	}
+
+	for _, reviewFile := range reviewFiles {
+		reviewData, err := internalUtils.ReadCoursesFromJSON[model.InstructorReviewData](reviewFile)
+		if err != nil {
+			fmt.Printf("Error reading review file %s: %v\n", reviewFile, err)
+			continue
+		}
+
+		relativePath, err := filepath.Rel(INSTRUCTOR_REVIEWS, reviewFile)
+		if err != nil {
+			continue
+		}
+
+		builder.Add(identity.Observation{
+			Name:        reviewData.ProfessorName,
+			Depts:       reviewCourseDepts(reviewData.Reviews),
+			ReviewFile:  filepath.ToSlash(relativePath),
+			ReviewCount: len(reviewData.Reviews),
+		})
+	}
+
+	identities := builder.Build()
+
+	jsonData, err := json.MarshalIndent(identities, "", "  ")
+	if err != nil {
+		fmt.Printf("Error marshaling alias table to JSON: %v\n", err)
+		return
+	}
+
+	if err := utils.WriteFileAtomic(RESULT_PATH, jsonData); err != nil {
+		fmt.Printf("Error writing alias table: %v\n", err)
+		return
+	}
+
+	withEmail := lo.CountBy(identities, func(i model.InstructorIdentity) bool { return len(i.Emails) > 0 })
+	withReviews := lo.CountBy(identities, func(i model.InstructorIdentity) bool { return len(i.ReviewFiles) > 0 })
+	withAliases := lo.CountBy(identities, func(i model.InstructorIdentity) bool { return len(i.Aliases) > 1 })
+	fmt.Printf("Successfully wrote %d instructor identities to %s\n", len(identities), RESULT_PATH)
+	fmt.Printf("With email: %d, with reviews: %d, with multiple names: %d\n", withEmail, withReviews, withAliases)
+}
+
+// reviewCourseDepts returns the department prefix of every reviewed course code,
+// one entry per review so that frequent departments weigh more.
+func reviewCourseDepts(reviews []model.Review) []string {
+	depts := make([]string, 0, len(reviews))
+	for _, review := range reviews {
+		if m := courseDeptPattern.FindStringSubmatch(review.CourseCode); m != nil {
+			depts = append(depts, strings.ToUpper(m[1]))
+		}
+	}
+	return depts
+}
@@ -62,7 +62,7 @@ func main() {
 
 	outlines, err := internalUtils.ReadCoursesFromJSON[[]model.CourseOutline](BASE_PATH + "/outlines.json")
 	if err != nil {
-		fmt.Errorf("Error reading courses from JSON, %s", err.Error())
+		fmt.Printf("Error reading courses from JSON: %v\n", err)
 		return
 	}
 	for i := range outlines {
@@ -81,7 +81,7 @@ func main() {
 	for _, term := range termCodes {
 		courses, err := internalUtils.ReadCoursesFromJSON[[]model.CourseWithSectionDetails](BASE_PATH + fmt.Sprintf("/sections/%s.json", term))
 		if err != nil {
-			fmt.Errorf("Error reading schedules from JSON %s", term)
+			fmt.Printf("Error reading schedules from JSON %s: %v\n", term, err)
 		}
 		coursesMap[term] = courses
 	}
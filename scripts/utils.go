@@ -7,6 +7,7 @@ import (
 	"maps"
 	"net/http"
 	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 	"time"
@@ -285,13 +286,40 @@ func ProcessAndWriteOutlines(outlineMap map[string]model.CourseOutline, destPath
 	}
 
 	// Write to file
-	if err := os.WriteFile(destPath, jsonData, 0644); err != nil {
+	if err := WriteFileAtomic(destPath, jsonData); err != nil {
 		return fmt.Errorf("error writing to file: %w", err)
 	}
 
 	return nil
 }
 
+// WriteFileAtomic writes data to a temporary file next to path and renames it
+// into place, so that readers never see a partly written file, even if the
+// script is killed midway, such as by the API shutting down during a sync.
+func WriteFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
 func TransformDate(inputDate string) (string, error) {
 	parsedTime, err := time.Parse("Mon Jan 02 15:04:05 MST 2006", inputDate)
 	if err != nil {